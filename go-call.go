@@ -0,0 +1,99 @@
+package go_console
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DrSmithFr/go-console/input"
+	"strings"
+)
+
+// Call run another script of the command (e.g. "cache:clear") with the given arguments,
+// sharing the output and the verbosity, and return its exit code without exiting the process
+func (s *Script) Call(name string, args []string) (ExitCode, error) {
+	return s.CallInput(name, input.NewArgvInput(append([]string{name}, args...)))
+}
+
+// CallInput run another script of the command with the given input (e.g. input.NewArrayInput),
+// sharing the output and the verbosity, and return its exit code without exiting the process
+func (s *Script) CallInput(name string, in input.InputInterface) (ExitCode, error) {
+	target := s.findCallable(name)
+
+	if target == nil {
+		return ExitInvalid, errors.New(fmt.Sprintf("command '%s' is not defined", name))
+	}
+
+	for caller := s; caller != nil; caller = caller.caller {
+		if caller == target {
+			return ExitError, errors.New(fmt.Sprintf("command '%s' is already running", name))
+		}
+	}
+
+	// the called script may change the verbosity and the decoration of the shared output
+	level := s.output.Verbosity()
+	decorated := s.output.IsDecorated()
+	errorOutput := s.ErrorStyle().output
+	errorDecorated := errorOutput.IsDecorated()
+
+	defer func() {
+		s.output.SetVerbosity(level)
+		s.output.SetDecorated(decorated)
+		errorOutput.SetDecorated(errorDecorated)
+	}()
+
+	target.Input = in
+	target.Output = s.output
+	target.inputParsed = false
+	target.caller = s
+	target.command = s.command
+	target.persistentOptions = s.persistentOptions
+	target.persistentConfig = s.persistentConfig
+	target.persistentSyntax = s.persistentSyntax
+
+	defer func() {
+		target.caller = nil
+	}()
+
+	if s.stream != nil {
+		target.SetStream(s.stream)
+	}
+
+	code, handled, err := target.prepare(s.Context())
+
+	if err != nil || handled {
+		return code, err
+	}
+
+	// share the buffered stream, answers read ahead by the caller belong to the target
+	target.questionHelper = s.QuestionHelper()
+
+	if target.Runner == nil {
+		// group of scripts without runner of its own
+		target.showHelp()
+		return ExitInvalid, errors.New(fmt.Sprintf("command '%s' requires a sub-command", target.fullName()))
+	}
+
+	return target.run(target.Runner)
+}
+
+// findCallable look for the script in the command running this script,
+// or among the sub-scripts of a standalone script (e.g. "db migrate")
+func (s *Script) findCallable(name string) *Script {
+	if s.command != nil {
+		return s.command.Find(name)
+	}
+
+	root := s
+
+	for root.parent != nil {
+		root = root.parent
+	}
+
+	names := strings.Fields(name)
+	resolved, remaining := root.resolveSubScript(names)
+
+	if len(names) == 0 || len(remaining) > 0 || resolved == root {
+		return nil
+	}
+
+	return resolved
+}
@@ -1,15 +1,18 @@
 package go_console
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/DrSmithFr/go-console/formatter"
+	"github.com/DrSmithFr/go-console/helper"
 	"github.com/DrSmithFr/go-console/input"
 	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/definition"
 	"github.com/DrSmithFr/go-console/input/option"
 	"github.com/DrSmithFr/go-console/output"
 	"github.com/DrSmithFr/go-console/table"
-	"github.com/DrSmithFr/go-console/verbosity"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -21,51 +24,32 @@ type CommandRunner func(cmd *Script) ExitCode
 
 // NewCommand create a new console script
 func NewCommand() *Command {
-	argv := os.Args
-
-	if len(argv) > 2 {
-		argv = argv[0:2]
-	}
-
-	script := newCommandCustom(
-		input.NewArgvInput(argv),
-		output.NewCliOutput(true, nil),
-		true,
-	)
-
-	return script
-}
-
-// NewScriptCustom create a new script with custom input/output with or without default options
-func newCommandCustom(in input.InputInterface, out output.OutputInterface, AddDefaultOptions bool) *Command {
 	script := &Command{
 		inputParsed:      false,
-		definitionParsed: true,
+		definitionParsed: false,
 
 		Description: "Script to run a commands",
 		Scripts:     []*Script{},
 
 		registeredScripts: make(map[string]*Script),
 		runners:           make(map[string]CommandRunner),
+		aliases:           make(map[string]string),
 	}
 
+	out := output.NewConsoleOutput(nil)
+
 	// clone the formatter to retrieve styles and avoid state change
 	format := *out.Formatter()
 
 	// accessors
-	script.Input = in
 	script.Output = out
 
-	// enable style within the script
-	script.input = in
+	// enable style before the first execution
+	script.input = input.NewArgvInput([]string{script.appName()})
 	script.output = out
 	script.bufferedOutput = *output.NewBufferedOutput(false, &format)
 	script.maxLineLength = MaxLineLength
 
-	if AddDefaultOptions {
-		script.addDefaultOptions()
-	}
-
 	return script
 }
 
@@ -78,12 +62,26 @@ type Command struct {
 	Output output.OutputInterface
 	Input  input.InputInterface
 
+	// Options persistent options, available in every script
+	Options []Option
+
+	// ConfigFile read argument and option values from a configuration file, available in every script
+	ConfigFile *ConfigFile
+
+	// Syntax command line syntax (e.g. definition.GnuSyntax()), used by every script without a syntax of its own
+	Syntax *definition.Syntax
+
 	Scripts           []*Script
 	registeredScripts map[string]*Script
 	runners           map[string]CommandRunner
+	aliases           map[string]string
 
 	inputParsed      bool
 	definitionParsed bool
+	definition       definition.InputDefinition
+	argv             []string
+	stream           io.Reader
+	currentScript    *Script
 
 	BuildInfo *BuildInfo
 }
@@ -119,9 +117,15 @@ func (c *Command) addDefaultOptions() {
 				SetDescription("Do not output any message"),
 		).
 		addInputOption(
-			option.New("verbose", option.Optional).
-				SetShortcut("v|vv|vvv").
-				SetDescription("Increase the verbosity of messages: 1 for normal output, 2 for more verbose output and 3 for debug"),
+			option.New("verbose", option.Count).
+				SetShortcut("v").
+				SetDescription("Increase the verbosity of messages: -v for normal output, -vv for more verbose output and -vvv for debug"),
+		).
+		// add decoration option
+		addInputOption(
+			option.
+				New("ansi", option.Negatable).
+				SetDescription("Force (or disable --no-ansi) ANSI output"),
 		)
 
 	if c.BuildInfo != nil {
@@ -158,23 +162,121 @@ func (c *Command) addInputArgument(arg *argument.InputArgument) *Command {
 
 // AddScript add a command to the script (fluent)
 func (c *Command) AddScript(cmd *Script, run CommandRunner) *Command {
-	if c.registeredScripts[cmd.Name] != nil {
+	if c.registeredScripts[cmd.Name] != nil || c.aliases[cmd.Name] != "" {
 		panic(errors.New(fmt.Sprintf("Script '%s' already exists", cmd.Name)))
 	}
 
+	for _, alias := range cmd.Aliases {
+		if c.registeredScripts[alias] != nil || c.aliases[alias] != "" {
+			panic(errors.New(fmt.Sprintf("Script alias '%s' already exists", alias)))
+		}
+	}
+
+	// keep the runner on the script, to be called by other scripts
+	if cmd.Runner == nil {
+		cmd.Runner = run
+	}
+
 	c.registeredScripts[cmd.Name] = cmd
 	c.runners[cmd.Name] = run
+
+	for _, alias := range cmd.Aliases {
+		c.aliases[alias] = cmd.Name
+	}
+
+	return c
+}
+
+// printUnknownScript display the unknown script error, suggesting the closest script names
+func (c *Command) printUnknownScript(command string) {
+	messages := []string{fmt.Sprintf("Command '%s' is not defined.", command)}
+
+	names := []string{}
+
+	for _, key := range c.ScriptOrderByName() {
+		if !c.Script(key).Hidden {
+			names = append(names, key)
+		}
+	}
+
+	for alias, key := range c.aliases {
+		if !c.Script(key).Hidden {
+			names = append(names, alias)
+		}
+	}
+
+	if suggestion := helper.DidYouMean(helper.Alternatives(command, names)); suggestion != "" {
+		messages = append(messages, suggestion)
+	}
+
+	c.PrintErrors(messages)
+}
+
+// resolveAlias return the script name for the given alias (or the given name when not an alias)
+func (c *Command) resolveAlias(name string) string {
+	if target, ok := c.aliases[name]; ok {
+		return target
+	}
+
+	return name
+}
+
+// SetStream set the reader used by scripts to answer questions (default: os.Stdin)
+func (c *Command) SetStream(stream io.Reader) *Command {
+	c.stream = stream
 	return c
 }
 
+// CurrentScript return the script resolved by the last Execute call
+func (c *Command) CurrentScript() *Script {
+	return c.currentScript
+}
+
 // Script return a script by name
 func (c *Command) Script(name string) *Script {
-	return c.registeredScripts[name]
+	return c.registeredScripts[c.resolveAlias(name)]
+}
+
+// Find return the script matching the name, an alias or a namespace abbreviation (when UseNamespace is enabled),
+// nested scripts are separated by spaces (e.g. "db migrate up"), nil when not found
+func (c *Command) Find(name string) *Script {
+	names := strings.Fields(name)
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	script := c.Script(names[0])
+
+	if script == nil && c.UseNamespace {
+		if found := c.FindScriptOrderByName(names[0]); len(found) == 1 {
+			script = c.Script(found[0])
+		}
+	}
+
+	// scripts are registered on Execute
+	for _, cmd := range c.Scripts {
+		if script == nil && cmd.Name == names[0] {
+			script = cmd
+		}
+	}
+
+	if script == nil {
+		return nil
+	}
+
+	resolved, remaining := script.resolveSubScript(names[1:])
+
+	if len(remaining) > 0 {
+		return nil
+	}
+
+	return resolved
 }
 
 // Runner return a command runner by command name
 func (c *Command) Runner(name string) CommandRunner {
-	return c.runners[name]
+	return c.runners[c.resolveAlias(name)]
 }
 
 // ScriptOrderByName return a list of command name sorted by name
@@ -207,130 +309,223 @@ func (c *Command) FindScriptOrderByName(search string) []string {
 	}
 
 	regex := regexp.MustCompile(pattern)
-	names := []string{}
+	found := map[string]bool{}
 
-	for key := range c.registeredScripts {
-		if regex.MatchString(key) {
-			names = append(names, key)
+	// hidden scripts can only be called by their exact name or alias
+	for key, cmd := range c.registeredScripts {
+		if !cmd.Hidden && regex.MatchString(key) {
+			found[key] = true
+		}
+	}
+
+	for alias, key := range c.aliases {
+		if !c.registeredScripts[key].Hidden && regex.MatchString(alias) {
+			found[key] = true
 		}
 	}
 
+	names := []string{}
+
+	for key := range found {
+		names = append(names, key)
+	}
+
 	sort.Strings(names)
 
 	return names
 }
 
+// Run parse os.Args and run the matching script, exiting the process on completion
 func (c *Command) Run() {
-	c.build()
+	code, _ := c.Execute(context.Background(), os.Args)
+	os.Exit(int(code))
+}
+
+// Execute parse the given argv and run the matching script, returning its exit code instead of exiting the process
+func (c *Command) Execute(ctx context.Context, argv []string) (ExitCode, error) {
+	if argv == nil {
+		argv = os.Args
+	}
+
+	c.argv = argv
+	c.currentScript = nil
+
+	if len(argv) > 1 && argv[1] == CompleteScriptName {
+		return c.runComplete(argv[2:])
+	}
+
+	if err := c.build(); err != nil {
+		return ExitInvalid, err
+	}
 
 	if c.BuildInfo != nil && option.Defined == c.input.Option("version") {
 		c.showVersion()
 
-		os.Exit(int(ExitSuccess))
+		return ExitSuccess, nil
 	}
 
 	command := c.input.Argument("command")
+	argv = c.commandArgv()
+
+	var nested []string
+
+	// nested script names given with the command name (e.g. {"command": "db migrate"})
+	if names := strings.Fields(command); argv == nil && len(names) > 1 {
+		command, nested = names[0], names[1:]
+	}
 
 	if command == "" {
 		c.showHelp()
 
 		if option.Defined == c.input.Option("help") {
-			os.Exit(int(ExitSuccess))
+			return ExitSuccess, nil
 		}
 
-		os.Exit(int(ExitInvalid))
+		return ExitInvalid, errors.New("no command given")
 	}
 
+	command = c.resolveAlias(command)
 	script := c.Script(command)
 
 	if script == nil && !c.UseNamespace {
-		c.PrintError(fmt.Sprintf("Command '%s' is not defined.", command))
-		os.Exit(int(ExitInvalid))
+		c.printUnknownScript(command)
+		return ExitInvalid, errors.New(fmt.Sprintf("command '%s' is not defined", command))
 	}
 
 	if script == nil && c.UseNamespace {
 		scripts := c.FindScriptOrderByName(command)
 
 		if len(scripts) == 0 {
-			c.PrintError(fmt.Sprintf("Command '%s' is not defined.", command))
-			os.Exit(int(ExitInvalid))
+			c.printUnknownScript(command)
+			return ExitInvalid, errors.New(fmt.Sprintf("command '%s' is not defined", command))
 		}
 
 		if len(scripts) > 1 {
 			// show possible commands
 			c.showAutocompletionHelp(command, scripts)
-			os.Exit(int(ExitInvalid))
-		} else {
-			// autocompleted command
-			command = scripts[0]
-			script = c.Script(command)
+			return ExitInvalid, errors.New(fmt.Sprintf("command '%s' is ambiguous", command))
 		}
+
+		// autocompleted command
+		command = scripts[0]
+		script = c.Script(command)
 	}
 
 	run := c.Runner(command)
 
-	if run == nil {
-		_, err := fmt.Fprintf(c.output, "<error>Script '%s' must have runner to work within script.</error>", command)
+	if run == nil && len(script.Scripts) == 0 {
+		_, err := fmt.Fprintf(c.ErrorStyle().output, "<error>Script '%s' must have runner to work within script.</error>", command)
 
 		if err != nil {
 			panic(err)
 		}
 
-		os.Exit(int(ExitError))
+		return ExitError, errors.New(fmt.Sprintf("script '%s' has no runner", command))
+	}
+
+	leading, trailing := []string{}, nested
+
+	if argv != nil {
+		leading, trailing = splitCommandTokens(&c.definition, argv)
 	}
 
-	argv := os.Args
+	// walk nested scripts (e.g. "app db migrate up")
+	script.SetParentScriptName(c.appName())
+	script.persistentOptions = c.Options
+	script.persistentConfig = c.ConfigFile
+	script.persistentSyntax = c.Syntax
+	resolved, tokens := script.resolveSubScript(trailing)
+
+	if resolved != script {
+		script = resolved
+		run = script.Runner
+	}
+
+	if argv == nil && len(tokens) > 0 {
+		name := strings.Join(append([]string{command}, nested...), " ")
+		c.printUnknownScript(name)
+		return ExitInvalid, errors.New(fmt.Sprintf("command '%s' is not defined", name))
+	}
 
 	// setup script
-	script.Input = input.NewArgvInput(argv[1:])
-	script.SetParentScriptName(argv[0])
+	script.Input = c.scriptInput(command, leading, tokens)
 	script.Output = c.output
+	script.inputParsed = false
+	script.command = c
+
+	if c.stream != nil {
+		script.SetStream(c.stream)
+	}
+
+	c.currentScript = script
+
+	code, handled, err := script.prepare(ctx)
+
+	if err != nil || handled {
+		return code, err
+	}
 
-	script.Build()
-	os.Exit(int(run(script)))
+	if run == nil {
+		// group of scripts without runner of its own
+		script.showHelp()
+		return ExitInvalid, errors.New(fmt.Sprintf("command '%s' requires a sub-command", script.fullName()))
+	}
+
+	return script.run(run)
 }
 
-// Run parse Definition and input and handle all the script logic
-func (c *Command) build() {
+// build parse Definition and input and handle all the script logic
+func (c *Command) build() error {
 	if !c.definitionParsed {
 		c.parseDefinition()
 		c.definitionParsed = true
+	} else {
+		c.syncOutput()
+	}
+
+	// each execution parses a new input
+	c.inputParsed = false
+
+	if err := c.parseInput(); err != nil {
+		return err
+	}
+
+	if err := c.validateInput(); err != nil {
+		return err
 	}
 
-	c.parseInput()
-	c.validateInput()
 	c.findOutputVerbosity()
+	c.findOutputDecoration()
 	c.registerCommands()
+
+	return nil
 }
 
 func (c *Command) registerCommands() {
 	for _, cmd := range c.Scripts {
-		if cmd.Runner == nil {
+		if cmd.Runner == nil && len(cmd.Scripts) == 0 {
 			panic(errors.New(fmt.Sprintf("Script '%s' has no runner", cmd.Name)))
 		}
 
+		// already registered by a previous execution
+		if c.registeredScripts[cmd.Name] == cmd {
+			continue
+		}
+
 		c.AddScript(cmd, cmd.Runner)
 	}
-}
 
-func (c *Command) parseDefinition() {
-	argv := os.Args
-
-	if len(argv) > 2 {
-		argv = argv[0:2]
+	if c.Script(CompletionScriptName) == nil {
+		completion := c.completionScript()
+		c.AddScript(completion, completion.Runner)
 	}
+}
 
-	var in input.InputInterface
+func (c *Command) parseDefinition() {
 	var out output.OutputInterface
 
-	if c.Input == nil {
-		in = input.NewArgvInput(argv)
-	} else {
-		in = c.Input
-	}
-
 	if c.Output == nil {
-		out = output.NewCliOutput(true, nil)
+		out = output.NewConsoleOutput(nil)
 	} else {
 		out = c.Output
 	}
@@ -339,81 +534,195 @@ func (c *Command) parseDefinition() {
 	format := *out.Formatter()
 
 	// accessors
-	c.Input = in
 	c.Output = out
 
-	// enable style within the script
-	c.input = in
+	// enable style within the script, the input holds the definition until the first parsing
+	c.input = input.NewArgvInput([]string{c.appName()})
 	c.output = out
 	c.bufferedOutput = *output.NewBufferedOutput(false, &format)
 	c.maxLineLength = MaxLineLength
 
 	c.addDefaultOptions()
+
+	for _, opt := range c.Options {
+		c.addInputOption(opt.inputOption())
+	}
+
+	if c.Syntax != nil {
+		c.input.Definition().SetSyntax(*c.Syntax)
+	}
+
+	if c.ConfigFile != nil {
+		c.addInputOption(c.ConfigFile.inputOption())
+	}
+
 	c.inputParsed = false
 
-	c.registeredScripts = make(map[string]*Script)
-	c.runners = make(map[string]CommandRunner)
+	if c.registeredScripts == nil {
+		c.registeredScripts = make(map[string]*Script)
+	}
 
+	if c.runners == nil {
+		c.runners = make(map[string]CommandRunner)
+	}
+
+	if c.aliases == nil {
+		c.aliases = make(map[string]string)
+	}
+
+	c.definition = *c.input.Definition()
 }
 
-func (c *Command) parseInput() *Command {
+// syncOutput apply an Output replaced after the first execution
+func (c *Command) syncOutput() {
+	if c.Output == nil || c.Output == c.output {
+		return
+	}
+
+	// clone the formatter to retrieve styles and avoid state change
+	format := *c.Output.Formatter()
+
+	c.output = c.Output
+	c.bufferedOutput = *output.NewBufferedOutput(false, &format)
+}
+
+func (c *Command) parseInput() (err error) {
+	defer c.handleParsingException(&err)
+
 	if c.inputParsed {
 		panic(errors.New("argv is already parsed"))
 	}
 
-	defer c.handleParsingException()
+	// the input given to the command may be shared with the script, its definition is set again on each execution
+	in := c.commandInput()
+	*in.Definition() = c.definition
+	c.input = in
 
 	c.input.Parse()
 	c.inputParsed = true
 
-	return c
+	return nil
+}
+
+// commandInput return the input parsed by the command: the options given before the command name and the command name
+func (c *Command) commandInput() input.InputInterface {
+	if argv := c.commandArgv(); argv != nil {
+		leading, _ := splitCommandTokens(&c.definition, argv)
+
+		if len(argv) > len(leading)+2 {
+			argv = argv[0 : len(leading)+2]
+		}
+
+		return input.NewArgvInput(argv)
+	}
+
+	if array, ok := c.Input.(*input.ArrayInput); ok {
+		return input.NewArrayInput(commandParameters(&c.definition, array.Parameters()))
+	}
+
+	return c.Input
+}
+
+// scriptInput return the input of the script: the options given before the command name and the tokens following it,
+// or the parameters of an ArrayInput without the command name
+func (c *Command) scriptInput(command string, leading []string, tokens []string) input.InputInterface {
+	if c.commandArgv() != nil {
+		return input.NewArgvInput(append(append([]string{command}, leading...), tokens...))
+	}
+
+	if array, ok := c.Input.(*input.ArrayInput); ok {
+		parameters := map[string]interface{}{}
+
+		for key, value := range array.Parameters() {
+			if key != "command" {
+				parameters[key] = value
+			}
+		}
+
+		return input.NewArrayInput(parameters)
+	}
+
+	return c.Input
+}
+
+// commandArgv return the command line of the input (program name included),
+// nil when the input is not made of tokens (e.g. ArrayInput)
+func (c *Command) commandArgv() []string {
+	if c.Input == nil {
+		return c.argv
+	}
+
+	if tokenized, ok := c.Input.(tokenInput); ok {
+		return append([]string{c.appName()}, tokenized.Tokens()...)
+	}
+
+	return nil
+}
+
+// commandParameters keep the command name and the options of the command definition (e.g. --env, -q)
+func commandParameters(def *definition.InputDefinition, parameters map[string]interface{}) map[string]interface{} {
+	filtered := map[string]interface{}{}
+
+	for key, value := range parameters {
+		name := strings.TrimPrefix(key, "--")
+
+		switch {
+		case key == "command":
+		case name != key && (def.HasOption(name) || isNegation(def, name)):
+		case name == key && strings.HasPrefix(key, "-") && def.HasShortcut(key[1:]):
+		default:
+			continue
+		}
+
+		filtered[key] = value
+	}
+
+	return filtered
 }
 
-func (c *Command) validateInput() *Command {
+func (c *Command) validateInput() (err error) {
 	if !c.inputParsed {
 		panic(errors.New("cannot validate unparsed input"))
 	}
 
-	defer c.handleParsingException()
+	defer c.handleParsingException(&err)
 	c.input.Validate()
 
+	return nil
+}
+
+func (c *Command) findOutputDecoration() *Command {
+	applyDecoration(c.input, c.output)
+
 	return c
 }
 
 func (c *Command) findOutputVerbosity() *Command {
-	level := verbosity.Normal
-
-	if c.input.Option("quiet") == option.Defined {
-		level = verbosity.Quiet
-	} else if c.input.Option("verbose") == option.Defined {
-		lvl := c.input.Option("verbose")
-		if lvl == "vv" {
-			level = verbosity.Debug
-		} else if lvl == "v" {
-			level = verbosity.VeryVerbose
-		}
-	}
-
-	c.output.SetVerbosity(level)
+	c.output.SetVerbosity(verbosityLevel(c.input))
 
 	return c
 }
 
-func (c *Command) handleParsingException() {
-	err := recover()
+// handleParsingException recover parsing panics, display them with the usage and store them into err
+func (c *Command) handleParsingException(err *error) {
+	recovered := recover()
 
-	if err == nil {
+	if recovered == nil {
 		// nothing append, continue
 		return
 	}
 
-	_, err1 := fmt.Fprintf(c.output, "<error>%s</error>", err)
+	c.printParsingException(recovered)
 
-	if err1 != nil {
-		panic(err1)
-	}
+	*err = toError(recovered)
+}
 
-	args := os.Args[0]
+// printParsingException display the parsing error with the usage
+func (c *Command) printParsingException(recovered interface{}) {
+	errorStyle := c.ErrorStyle()
+	errorStyle.PrintError(fmt.Sprintf("%s", recovered))
+
+	args := c.appName()
 	synopsis := c.input.Definition().Synopsis(false)
 
 	usage := fmt.Sprintf(
@@ -422,11 +731,11 @@ func (c *Command) handleParsingException() {
 		formatter.Escape(synopsis),
 	)
 
-	c.output.Println(usage)
-
-	os.Exit(2)
+	errorStyle.output.Println(usage)
 }
 
+// HandleRuntimeException display the recovered panic and exit the process,
+// within Execute the exit code and the error are returned instead
 func (c *Command) HandleRuntimeException() {
 	err := recover()
 
@@ -435,21 +744,33 @@ func (c *Command) HandleRuntimeException() {
 		return
 	}
 
+	// same display and exit code as a script handling its own exceptions
+	if c.currentScript != nil {
+		c.currentScript.exit(c.currentScript.displayException(err))
+	}
+
+	if invalid, ok := err.(*input.InvalidValueError); ok {
+		c.printParsingException(invalid)
+		os.Exit(int(ExitInvalid))
+	}
+
 	msg := fmt.Sprintf("%s", err)
 	full := fmt.Sprintf("%+v", err)
 
 	traces := strings.TrimPrefix(full, msg)
 	traces = strings.Replace(traces, "\n\t", "() at ", -1)
 
-	_, err1 := fmt.Fprintf(c.output, "<error>%s</error>", msg)
+	errorOutput := c.ErrorStyle().output
+
+	_, err1 := fmt.Fprintf(errorOutput, "<error>%s</error>", msg)
 
 	if err1 != nil {
 		panic(err1)
 	}
 
-	c.output.Print("<comment>Exception trace:</comment>")
+	errorOutput.Print("<comment>Exception trace:</comment>")
 	for _, trace := range strings.Split(traces, "\n") {
-		c.output.Println(
+		errorOutput.Println(
 			fmt.Sprintf(
 				" %s",
 				formatter.Escape(trace),
@@ -457,7 +778,48 @@ func (c *Command) HandleRuntimeException() {
 		)
 	}
 
-	os.Exit(2)
+	os.Exit(int(ExitInvalid))
+}
+
+// splitCommandTokens split argv tokens around the command name, leading holds the options given before it
+// with their separate values (e.g. --env prod)
+func splitCommandTokens(def *definition.InputDefinition, argv []string) (leading []string, trailing []string) {
+	index := commandTokenIndex(def, argv[1:])
+
+	if index == -1 {
+		return argv[1:], []string{}
+	}
+
+	return argv[1 : index+1], argv[index+2:]
+}
+
+// commandTokenIndex return the index of the command name within the tokens, -1 when not given
+func commandTokenIndex(def *definition.InputDefinition, tokens []string) int {
+	for index := 0; index < len(tokens); index++ {
+		token := tokens[index]
+
+		if token == "" || token[0] != '-' {
+			return index
+		}
+
+		// skip the value given after the option (e.g. --env prod)
+		_, separateValue := inspectOptionToken(def, token)
+
+		if separateValue && index+1 < len(tokens) && (tokens[index+1] == "" || tokens[index+1][0] != '-') {
+			index++
+		}
+	}
+
+	return -1
+}
+
+// appName return the executable name as given in argv
+func (c *Command) appName() string {
+	if len(c.argv) > 0 {
+		return c.argv[0]
+	}
+
+	return os.Args[0]
 }
 
 func (c *Command) showHelp() {
@@ -481,7 +843,7 @@ func (c *Command) showHelp() {
 }
 
 func (c *Command) showVersion() {
-	appName := filepath.Base(os.Args[0])
+	appName := filepath.Base(c.appName())
 
 	if c.BuildInfo == nil {
 		c.PrintText(fmt.Sprintf(
@@ -538,45 +900,7 @@ func (c *Command) displayOptsHelper(render table.TableRender) {
 	c.PrintNewLine(1)
 	c.PrintText("<comment>Options:</comment>")
 
-	optTab := table.NewTable()
-
-	for _, optKey := range c.input.Definition().OptionsOrder() {
-		opt := c.input.Definition().Option(optKey)
-		shortcut := ""
-
-		if opt.Shortcut() != "" {
-			shortcut = fmt.Sprintf(
-				"<info>-%s,</info>",
-				opt.Shortcut(),
-			)
-		}
-
-		name := fmt.Sprintf(
-			" <info>--%s</info>",
-			opt.Name(),
-		)
-
-		desc := opt.Description()
-
-		if !opt.IsList() && opt.Default() != "" {
-			desc += fmt.Sprintf(
-				" <comment>[default: \"%s\"]</comment>",
-				opt.Default(),
-			)
-		}
-
-		if opt.IsList() && opt.Defaults() != nil {
-			desc += fmt.Sprintf(
-				" <comment>[defaults: [[\"%s\"]]</comment>",
-				strings.Join(opt.Defaults(), "\", \""),
-			)
-		}
-
-		optTab.
-			AddRowFromString([]string{
-				shortcut, name, desc,
-			})
-	}
+	optTab := createOptionsTable(c.input.Definition())
 
 	render.
 		SetContent(optTab).
@@ -592,6 +916,10 @@ func (c *Command) displayAllScriptsTable(render table.TableRender) {
 	for _, key := range c.ScriptOrderByName() {
 		cmd := c.Script(key)
 
+		if cmd.Hidden {
+			continue
+		}
+
 		name := fmt.Sprintf(
 			" <info>%s</info>",
 			cmd.Name,
@@ -599,7 +927,7 @@ func (c *Command) displayAllScriptsTable(render table.TableRender) {
 
 		argTab.
 			AddRowFromString([]string{
-				name, cmd.Description,
+				name, cmd.describe(),
 			})
 	}
 
@@ -617,6 +945,10 @@ func (c *Command) displayAllScriptsByNamespacesTable(render table.TableRender) {
 	for _, key := range c.ScriptOrderByName() {
 		cmd := c.Script(key)
 
+		if cmd.Hidden {
+			continue
+		}
+
 		namespace := strings.Split(cmd.Name, ":")[0]
 
 		name := fmt.Sprintf(
@@ -644,7 +976,7 @@ func (c *Command) displayAllScriptsByNamespacesTable(render table.TableRender) {
 
 		argTab.
 			AddRowFromString([]string{
-				name, cmd.Description,
+				name, cmd.describe(),
 			})
 	}
 
@@ -669,7 +1001,7 @@ func (c *Command) displayScriptsTable(command string, script []string, render ta
 
 		argTab.
 			AddRowFromString([]string{
-				name, cmd.Description,
+				name, cmd.describe(),
 			})
 	}
 
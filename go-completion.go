@@ -0,0 +1,372 @@
+package go_console
+
+import (
+	"fmt"
+	"github.com/DrSmithFr/go-console/formatter"
+	"github.com/DrSmithFr/go-console/helper"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// CompletionScriptName name of the built-in script dumping the shell completion script
+	CompletionScriptName = "completion"
+
+	// CompleteScriptName name of the hidden script used by shells to fetch completion candidates
+	CompleteScriptName = "__complete"
+)
+
+var completionTemplates = map[string]string{
+	"bash": `# bash completion for {{name}}, add this line to your ~/.bashrc:
+# eval "$({{prog}} completion bash)"
+_{{func}}_completion()
+{
+    local line="${COMP_LINE:0:COMP_POINT}"
+    local -a words
+    read -ra words <<< "$line"
+    [[ "$line" == *" " ]] && words+=("")
+
+    local cur="${words[${#words[@]}-1]}"
+    local prefix="${cur%"${COMP_WORDS[COMP_CWORD]}"}"
+
+    local IFS=$'\n'
+    local -a candidates
+    candidates=($("{{prog}}" __complete "${words[@]:1}" 2>/dev/null))
+
+    COMPREPLY=("${candidates[@]#"$prefix"}")
+}
+
+complete -o default -F _{{func}}_completion {{name}}
+`,
+	"zsh": `#compdef {{name}}
+# zsh completion for {{name}}, add this line to your ~/.zshrc:
+# eval "$({{prog}} completion zsh)"
+_{{func}}()
+{
+    local -a candidates
+    candidates=(${(f)"$("{{prog}}" __complete "${(@)words[2,CURRENT]}" 2>/dev/null)"})
+
+    compadd -Q -- "${candidates[@]}"
+}
+
+compdef _{{func}} {{name}}
+`,
+	"fish": `# fish completion for {{name}}, add this line to your ~/.config/fish/config.fish:
+# {{prog}} completion fish | source
+function __{{func}}_complete
+    set -l tokens (commandline -opc) (commandline -ct)
+    "{{prog}}" __complete $tokens[2..-1] 2>/dev/null
+end
+
+complete -c {{name}} -f -a '(__{{func}}_complete)'
+`,
+}
+
+// completionScript create the built-in script dumping the shell completion script
+func (c *Command) completionScript() *Script {
+	shell := filepath.Base(os.Getenv("SHELL"))
+
+	if _, ok := completionTemplates[shell]; !ok {
+		shell = ""
+	}
+
+	return &Script{
+		Name:        CompletionScriptName,
+		Description: "Dump the shell completion script",
+		Arguments: []Argument{
+			{
+				Name:         "shell",
+				Value:        argument.Optional,
+				Description:  "The shell type (bash, zsh or fish), guessed from $SHELL when omitted",
+				DefaultValue: shell,
+			},
+		},
+		Runner: c.runCompletionScript,
+	}
+}
+
+func (c *Command) runCompletionScript(cmd *Script) ExitCode {
+	shell := cmd.Input.Argument("shell")
+	template, ok := completionTemplates[shell]
+
+	if !ok {
+		cmd.PrintError(fmt.Sprintf("Shell '%s' is not supported, use one of: bash, zsh, fish", shell))
+		return ExitInvalid
+	}
+
+	prog := c.appName()
+	name := filepath.Base(prog)
+
+	// resolve relative paths (e.g. ./bin/app) to call the program from anywhere
+	if strings.ContainsRune(prog, os.PathSeparator) {
+		if abs, err := filepath.Abs(prog); err == nil {
+			prog = abs
+		}
+	}
+
+	replacer := strings.NewReplacer(
+		"{{name}}", name,
+		"{{prog}}", prog,
+		"{{func}}", regexp.MustCompile("[^a-zA-Z0-9_]").ReplaceAllString(name, "_"),
+	)
+
+	cmd.Output.Print(replacer.Replace(template))
+
+	return ExitSuccess
+}
+
+// runComplete print the completion candidates for the given words, the last one being the word to complete
+func (c *Command) runComplete(words []string) (ExitCode, error) {
+	if !c.definitionParsed {
+		c.parseDefinition()
+		c.definitionParsed = true
+	}
+
+	c.registerCommands()
+
+	for _, candidate := range c.complete(words) {
+		c.output.Println(formatter.Escape(candidate))
+	}
+
+	return ExitSuccess, nil
+}
+
+// complete return the completion candidates for the given words, the last one being the word to complete
+func (c *Command) complete(words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+
+	current := words[len(words)-1]
+	previous := words[:len(words)-1]
+
+	commandIndex := commandTokenIndex(&c.definition, previous)
+
+	// completing the command name or an option given before it
+	if commandIndex == -1 {
+		if candidates, ok := completeOptionValue(&c.definition, previous, current); ok {
+			return candidates
+		}
+
+		if strings.HasPrefix(current, "-") {
+			return completeOptions(&c.definition, current)
+		}
+
+		return filterCandidates(c.completableScriptNames(), current)
+	}
+
+	script := c.findCompletedScript(previous[commandIndex])
+
+	if script == nil {
+		return []string{}
+	}
+
+	script.SetParentScriptName(c.appName())
+	script.persistentOptions = c.Options
+	script.persistentConfig = c.ConfigFile
+	script.persistentSyntax = c.Syntax
+
+	script, remaining := script.resolveSubScript(previous[commandIndex+1:])
+	def := script.inputDefinition()
+
+	// options given before the command name and tokens following the (nested) script name
+	tokens := append(append([]string{}, previous[:commandIndex]...), remaining...)
+
+	if candidates, ok := completeOptionValue(def, tokens, current); ok {
+		return candidates
+	}
+
+	if strings.HasPrefix(current, "-") {
+		return completeOptions(def, current)
+	}
+
+	if len(remaining) == 0 && len(script.Scripts) > 0 {
+		var names []string
+
+		for _, sub := range script.Scripts {
+			if !sub.Hidden {
+				names = append(names, sub.Name)
+			}
+		}
+
+		return filterCandidates(names, current)
+	}
+
+	return completeArgumentValue(def, tokens, current)
+}
+
+// findCompletedScript find the script to complete, allowing namespace abbreviation
+func (c *Command) findCompletedScript(name string) *Script {
+	if script := c.Script(name); script != nil {
+		return script
+	}
+
+	if !c.UseNamespace {
+		return nil
+	}
+
+	scripts := c.FindScriptOrderByName(name)
+
+	if len(scripts) != 1 {
+		return nil
+	}
+
+	return c.Script(scripts[0])
+}
+
+// completableScriptNames return the names and aliases of all visible scripts
+func (c *Command) completableScriptNames() []string {
+	var names []string
+
+	for _, key := range c.ScriptOrderByName() {
+		if !c.Script(key).Hidden {
+			names = append(names, key)
+		}
+	}
+
+	for alias, key := range c.aliases {
+		if !c.Script(key).Hidden {
+			names = append(names, alias)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// completeOptions return the options names (or shortcuts) starting with the given word
+func completeOptions(def *definition.InputDefinition, current string) []string {
+	var candidates []string
+
+	for _, key := range def.OptionsOrder() {
+		opt := def.Option(key)
+
+		if current != "-" && !strings.HasPrefix(current, "--") {
+			if opt.Shortcut() == "" {
+				continue
+			}
+
+			for _, shortcut := range strings.Split(opt.Shortcut(), "|") {
+				candidates = append(candidates, "-"+shortcut)
+			}
+
+			continue
+		}
+
+		candidates = append(candidates, "--"+opt.Name())
+
+		if opt.IsNegatable() {
+			candidates = append(candidates, "--no-"+opt.Name())
+		}
+	}
+
+	return filterCandidates(candidates, current)
+}
+
+// completeOptionValue return the candidate values of the option being completed (as --name=value or --name value)
+func completeOptionValue(def *definition.InputDefinition, tokens []string, current string) ([]string, bool) {
+	if pos := strings.Index(current, "="); strings.HasPrefix(current, "--") && pos != -1 {
+		name := current[2:pos]
+
+		if !def.HasOption(name) {
+			return []string{}, true
+		}
+
+		candidates := def.Option(name).Complete(current[pos+1:], parsedInput(def, tokens))
+
+		return helper.Map(candidates, func(candidate string) string {
+			return current[:pos+1] + candidate
+		}), true
+	}
+
+	if len(tokens) == 0 {
+		return nil, false
+	}
+
+	last := tokens[len(tokens)-1]
+	var opt *option.InputOption
+
+	if strings.HasPrefix(last, "--") && !strings.Contains(last, "=") && def.HasOption(last[2:]) {
+		opt = def.Option(last[2:])
+	} else if len(last) > 1 && last[0] == '-' && last[1] != '-' && def.HasShortcut(last[1:]) {
+		opt = def.FindOptionForShortcut(last[1:])
+	}
+
+	// optional values are ambiguous with arguments, only complete required ones
+	if opt == nil || !opt.IsValueRequired() {
+		return nil, false
+	}
+
+	return opt.Complete(current, parsedInput(def, tokens[:len(tokens)-1])), true
+}
+
+// completeArgumentValue return the candidate values of the argument being completed
+func completeArgumentValue(def *definition.InputDefinition, tokens []string, current string) []string {
+	parsed := parsedInput(def, tokens)
+	keys := def.ArgumentsOrder()
+	index := len(parsed.Arguments()) + len(parsed.ArgumentArrays())
+
+	if index >= len(keys) {
+		if len(keys) == 0 || !def.Argument(keys[len(keys)-1]).IsList() {
+			return []string{}
+		}
+
+		index = len(keys) - 1
+	}
+
+	return def.Argument(keys[index]).Complete(current, parsed)
+}
+
+// parsedInput parse the tokens typed so far, keeping what has been parsed when the input is invalid
+func parsedInput(def *definition.InputDefinition, tokens []string) input.InputInterface {
+	in := input.NewArgvInput(append([]string{""}, tokens...))
+
+	func() {
+		// partially typed input are often invalid
+		defer func() {
+			_ = recover()
+		}()
+
+		in.Bind(*def)
+	}()
+
+	return in
+}
+
+// filterCandidates keep the candidates starting with the given prefix
+func filterCandidates(candidates []string, prefix string) []string {
+	filtered := []string{}
+
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) {
+			filtered = append(filtered, candidate)
+		}
+	}
+
+	return filtered
+}
+
+// inputDefinition return the script input definition, building it when needed
+func (s *Script) inputDefinition() *definition.InputDefinition {
+	if !s.definitionParsed {
+		s.parseDefinition()
+		s.definitionParsed = true
+	}
+
+	// a clashing option is reported when the script runs
+	_ = s.inheritOptions()
+
+	if syntax := s.syntax(); syntax != nil {
+		s.input.Definition().SetSyntax(*syntax)
+	}
+
+	return s.input.Definition()
+}
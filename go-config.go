@@ -0,0 +1,129 @@
+package go_console
+
+import (
+	"fmt"
+	"github.com/DrSmithFr/go-console/formatter"
+	"github.com/DrSmithFr/go-console/helper"
+	"github.com/DrSmithFr/go-console/input/config"
+	"github.com/DrSmithFr/go-console/input/option"
+	"strings"
+)
+
+// DefaultConfigOption name of the option giving the configuration file when ConfigFile.Option is empty
+const DefaultConfigOption = "config"
+
+// ConfigFile read argument and option values from a configuration file (json, yaml, toml or .env)
+// values are resolved in order: command line, environment, configuration file and default
+type ConfigFile struct {
+	// Option name of the option giving the file path (default: config)
+	Option string
+
+	// Paths files looked up in order when the option is not given
+	Paths []string
+}
+
+// optionName return the name of the option giving the file path
+func (f *ConfigFile) optionName() string {
+	if f.Option == "" {
+		return DefaultConfigOption
+	}
+
+	return f.Option
+}
+
+// inputOption create the option giving the file path
+func (f *ConfigFile) inputOption() *option.InputOption {
+	return option.
+		New(f.optionName(), option.Optional).
+		SetDescription("Read argument and option values from the given configuration file")
+}
+
+// configFile return the configuration file of the script, its parents or the command
+func (s *Script) configFile() *ConfigFile {
+	for script := s; script != nil; script = script.parent {
+		if script.ConfigFile != nil {
+			return script.ConfigFile
+		}
+	}
+
+	return s.persistentConfig
+}
+
+// loadConfig read the configuration file given by option or found by convention into the input
+func (s *Script) loadConfig() (err error) {
+	file := s.configFile()
+
+	if file == nil {
+		return nil
+	}
+
+	defer s.handleParsingException(&err)
+
+	s.input.SetConfig(nil)
+
+	path := s.input.Option(file.optionName())
+
+	if path == "" {
+		path = config.Find(file.Paths...)
+	}
+
+	if path == "" {
+		s.reportSources()
+		return nil
+	}
+
+	values, loadErr := config.Load(path)
+
+	if loadErr != nil {
+		panic(loadErr)
+	}
+
+	s.input.SetConfig(values)
+	s.reportSources()
+
+	return nil
+}
+
+// reportSources print where each argument and option value comes from (debug verbosity only)
+func (s *Script) reportSources() {
+	if !s.output.IsDebug() {
+		return
+	}
+
+	def := s.input.Definition()
+	messages := []string{}
+
+	if path := s.input.Config().Path(); path != "" {
+		messages = append(messages, fmt.Sprintf("Configuration file: %s", path))
+	} else {
+		messages = append(messages, "Configuration file: none")
+	}
+
+	for _, key := range def.ArgumentsOrder() {
+		arg := def.Argument(key)
+		value := ""
+
+		if arg.IsList() {
+			value = strings.Join(s.input.ArgumentList(key), ", ")
+		} else {
+			value = s.input.Argument(key)
+		}
+
+		messages = append(messages, fmt.Sprintf("%s = \"%s\" (%s)", key, value, s.input.ArgumentSource(key)))
+	}
+
+	for _, key := range def.OptionsOrder() {
+		opt := def.Option(key)
+		value := ""
+
+		if opt.IsList() {
+			value = strings.Join(s.input.OptionList(key), ", ")
+		} else {
+			value = s.input.Option(key)
+		}
+
+		messages = append(messages, fmt.Sprintf("--%s = \"%s\" (%s)", key, value, s.input.OptionSource(key)))
+	}
+
+	s.PrintComments(helper.Map(messages, formatter.Escape))
+}
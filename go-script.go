@@ -1,15 +1,21 @@
 package go_console
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/DrSmithFr/go-console/formatter"
 	"github.com/DrSmithFr/go-console/input"
 	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/completion"
+	"github.com/DrSmithFr/go-console/input/constraint"
+	"github.com/DrSmithFr/go-console/input/definition"
 	"github.com/DrSmithFr/go-console/input/option"
 	"github.com/DrSmithFr/go-console/output"
+	"github.com/DrSmithFr/go-console/question"
 	"github.com/DrSmithFr/go-console/table"
 	"github.com/DrSmithFr/go-console/verbosity"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -28,7 +34,7 @@ func NewScript() *Script {
 	// manage verbosity
 	cmd := NewScriptCustom(
 		input.NewArgvInput(nil),
-		output.NewCliOutput(true, nil),
+		output.NewConsoleOutput(nil),
 		true,
 	)
 
@@ -75,15 +81,46 @@ type Script struct {
 	Name        string
 	Description string
 
+	// Aliases other names the script can be called with
+	Aliases []string
+
+	// Hidden scripts can be called but are not listed
+	Hidden bool
+
 	Arguments []Argument
 	Options   []Option
 
+	// OptionGroups relations between options (e.g. definition.Exclusive("json", "table"))
+	OptionGroups []*definition.OptionGroup
+
+	// Syntax command line syntax (e.g. definition.GnuSyntax()), inherited by sub-scripts
+	Syntax *definition.Syntax
+
 	Runner CommandRunner
 
+	// Scripts nested sub-scripts (e.g. "app db migrate up"), inheriting the script options
+	Scripts []*Script
+
+	// ConfigFile read argument and option values from a configuration file, inherited by sub-scripts
+	ConfigFile *ConfigFile
+
+	// Bind struct pointer whose tagged fields declare the arguments and options and receive their values
+	Bind interface{}
+
 	// internal
-	inputParsed      bool
-	definitionParsed bool
-	parentScriptName string
+	inputParsed       bool
+	definitionParsed  bool
+	executing         bool
+	parentScriptName  string
+	parent            *Script
+	persistentOptions []Option
+	persistentConfig  *ConfigFile
+	persistentSyntax  *definition.Syntax
+	command           *Command
+	caller            *Script
+	ctx               context.Context
+	stream            io.Reader
+	questionHelper    *question.Helper
 
 	BuildInfo *BuildInfo
 }
@@ -93,6 +130,32 @@ func (s *Script) SetDescription(description string) *Script {
 	return s
 }
 
+// SetStream set the reader used to answer questions (default: os.Stdin)
+func (s *Script) SetStream(stream io.Reader) *Script {
+	s.stream = stream
+	s.questionHelper = nil
+	return s
+}
+
+// Stream return the reader used to answer questions
+func (s *Script) Stream() io.Reader {
+	if s.stream == nil {
+		return os.Stdin
+	}
+
+	return s.stream
+}
+
+// QuestionHelper return the question helper bound to the script stream and output,
+// the same helper is reused so that answers buffered from the stream are not lost
+func (s *Script) QuestionHelper() *question.Helper {
+	if s.questionHelper == nil {
+		s.questionHelper = question.NewHelper(s.Stream(), s.output)
+	}
+
+	return s.questionHelper
+}
+
 type Argument struct {
 	Name  string
 	Value int
@@ -101,6 +164,15 @@ type Argument struct {
 
 	DefaultValue  string
 	DefaultValues []string
+
+	// Env environment variable used when not given on the command line
+	Env string
+
+	// Constraint value type and rules checked when the input is validated
+	Constraint *constraint.Constraint
+
+	// Completion provide candidate values for shell completion
+	Completion completion.Provider
 }
 
 type Option struct {
@@ -112,6 +184,50 @@ type Option struct {
 
 	DefaultValue  string
 	DefaultValues []string
+
+	// Env environment variable used when not given on the command line
+	Env string
+
+	// Constraint value type and rules checked when the input is validated
+	Constraint *constraint.Constraint
+
+	// Completion provide candidate values for shell completion
+	Completion completion.Provider
+}
+
+// inputOption convert the option declaration into an InputOption
+func (o Option) inputOption() *option.InputOption {
+	newOpt := option.New(o.Name, o.Value)
+
+	if o.Shortcut != "" {
+		newOpt.SetShortcut(o.Shortcut)
+	}
+
+	if o.Description != "" {
+		newOpt.SetDescription(o.Description)
+	}
+
+	if o.DefaultValue != "" {
+		newOpt.SetDefault(o.DefaultValue)
+	}
+
+	if len(o.DefaultValues) > 0 {
+		newOpt.SetDefaults(o.DefaultValues)
+	}
+
+	if o.Env != "" {
+		newOpt.SetEnv(o.Env)
+	}
+
+	if o.Constraint != nil {
+		newOpt.SetConstraint(o.Constraint)
+	}
+
+	if o.Completion != nil {
+		newOpt.SetCompletion(o.Completion)
+	}
+
+	return newOpt
 }
 
 func (s *Script) addDefaultOptions() {
@@ -144,9 +260,15 @@ func (s *Script) addDefaultOptions() {
 				SetDescription("Do not output any message"),
 		).
 		AddInputOption(
-			option.New("verbose", option.Optional).
-				SetShortcut("v|vv|vvv").
-				SetDescription("Increase the verbosity of messages: 1 for normal output, 2 for more verbose output and 3 for debug"),
+			option.New("verbose", option.Count).
+				SetShortcut("v").
+				SetDescription("Increase the verbosity of messages: -v for normal output, -vv for more verbose output and -vvv for debug"),
+		).
+		// add decoration option
+		AddInputOption(
+			option.
+				New("ansi", option.Negatable).
+				SetDescription("Force (or disable --no-ansi) ANSI output"),
 		)
 }
 
@@ -166,6 +288,17 @@ func (s *Script) AddInputOption(opt *option.InputOption) *Script {
 	return s
 }
 
+// AddOptionGroup add a relation between options to input definition (fluent)
+func (s *Script) AddOptionGroup(group *definition.OptionGroup) *Script {
+	if s.inputParsed {
+		panic(errors.New("cannot add option group on parsed input"))
+	}
+
+	s.input.Definition().AddOptionGroup(group)
+
+	return s
+}
+
 // AddInputArgument add argument to input definition (fluent)
 func (s *Script) AddInputArgument(arg *argument.InputArgument) *Script {
 	if s.inputParsed {
@@ -177,24 +310,186 @@ func (s *Script) AddInputArgument(arg *argument.InputArgument) *Script {
 	return s
 }
 
+// Build parse the input and run the script runner (if defined), exiting the process on completion
 func (s *Script) Build() *Script {
+	// the runner of a nested script is always run
+	if len(s.Scripts) > 0 {
+		code, _ := s.Execute(context.Background())
+		os.Exit(int(code))
+	}
+
+	code, handled, err := s.prepare(context.Background())
+
+	if err != nil || handled {
+		os.Exit(int(code))
+	}
+
+	if s.Runner != nil {
+		os.Exit(int(s.Runner(s)))
+	}
+
+	return s
+}
+
+// Execute parse the input and run the script runner (if defined), returning its exit code instead of exiting the process
+func (s *Script) Execute(ctx context.Context) (ExitCode, error) {
+	script, err := s.dispatch()
+
+	if err != nil {
+		return ExitInvalid, err
+	}
+
+	// each execution parses the input again
+	script.inputParsed = false
+
+	code, handled, err := script.prepare(ctx)
+
+	if err != nil || handled {
+		return code, err
+	}
+
+	if script.Runner == nil && len(script.Scripts) > 0 {
+		// group of scripts without runner of its own
+		script.showHelp()
+		return ExitInvalid, errors.New(fmt.Sprintf("command '%s' requires a sub-command", script.fullName()))
+	}
+
+	if script.Runner == nil {
+		return ExitSuccess, nil
+	}
+
+	return script.run(script.Runner)
+}
+
+// tokenInput is implemented by the inputs made of command line tokens (ArgvInput, StringInput)
+type tokenInput interface {
+	Tokens() []string
+}
+
+// dispatch return the nested script named by the leading tokens of the input (e.g. "app db migrate"),
+// given an input made of the remaining tokens, or the script itself
+func (s *Script) dispatch() (script *Script, err error) {
+	if len(s.Scripts) == 0 {
+		return s, nil
+	}
+
+	s.inputDefinition()
+	s.syncAccessors()
+
+	defer s.handleParsingException(&err)
+
+	tokenized, ok := s.input.(tokenInput)
+
+	if !ok {
+		return s, nil
+	}
+
+	script, tokens := s.resolveSubScript(tokenized.Tokens())
+
+	if script == s {
+		return s, nil
+	}
+
+	script.Input = input.NewArgvInput(append([]string{script.fullName()}, tokens...))
+	script.Output = s.output
+
+	if s.stream != nil {
+		script.SetStream(s.stream)
+	}
+
+	return script, nil
+}
+
+// Context return the context given to Execute (background context when using Build)
+func (s *Script) Context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+
+	return s.ctx
+}
+
+// prepare parse and validate the input, handled is true when the call has been fully handled (help, version)
+func (s *Script) prepare(ctx context.Context) (code ExitCode, handled bool, err error) {
+	s.ctx = ctx
+
 	if !s.definitionParsed {
 		s.parseDefinition()
 		s.definitionParsed = true
+	} else {
+		s.syncAccessors()
+	}
+
+	if err = s.inheritOptions(); err != nil {
+		s.printParsingException(err)
+		return ExitInvalid, true, err
+	}
+
+	if syntax := s.syntax(); syntax != nil {
+		s.input.Definition().SetSyntax(*syntax)
+	}
+
+	if err = s.parseInput(); err != nil {
+		return ExitInvalid, true, err
 	}
 
-	s.parseInput()
 	s.findOutputVerbosity()
-	s.handleHelpCall()
-	s.handleVersionCall()
+	s.findOutputDecoration()
 
-	s.validateInput()
+	if s.handleHelpCall() || s.handleVersionCall() {
+		return ExitSuccess, true, nil
+	}
 
-	if s.Runner != nil {
-		os.Exit(int(s.Runner(s)))
+	if err = s.loadConfig(); err != nil {
+		return ExitInvalid, true, err
 	}
 
-	return s
+	if err = s.validateInput(); err != nil {
+		return ExitInvalid, true, err
+	}
+
+	if err = s.bindInput(); err != nil {
+		return ExitInvalid, true, err
+	}
+
+	return ExitSuccess, false, nil
+}
+
+// run the given runner, converting runtime panics into errors
+func (s *Script) run(runner CommandRunner) (code ExitCode, err error) {
+	s.executing = true
+
+	defer func() {
+		s.executing = false
+		recovered := recover()
+
+		if recovered == nil {
+			return
+		}
+
+		// already displayed by HandleRuntimeException
+		if handled, ok := recovered.(handledException); ok {
+			code = handled.code
+			err = handled.err
+			return
+		}
+
+		// typed accessors conversion errors are reported as invalid input
+		if invalid, ok := recovered.(*input.InvalidValueError); ok {
+			s.printParsingException(invalid)
+
+			code = ExitInvalid
+			err = invalid
+			return
+		}
+
+		s.printRuntimeException(recovered)
+
+		code = ExitError
+		err = toError(recovered)
+	}()
+
+	return runner(s), nil
 }
 
 func (s *Script) parseDefinition() *Script {
@@ -210,7 +505,7 @@ func (s *Script) parseDefinition() *Script {
 	if s.Output != nil {
 		out = s.Output
 	} else {
-		out = output.NewCliOutput(true, nil)
+		out = output.NewConsoleOutput(nil)
 	}
 
 	// clone the formatter to retrieve styles and avoid state change
@@ -234,78 +529,328 @@ func (s *Script) parseDefinition() *Script {
 				newArg.SetDefaults(arg.DefaultValues)
 			}
 
+			if arg.Env != "" {
+				newArg.SetEnv(arg.Env)
+			}
+
+			if arg.Constraint != nil {
+				newArg.SetConstraint(arg.Constraint)
+			}
+
+			if arg.Completion != nil {
+				newArg.SetCompletion(arg.Completion)
+			}
+
 			s.AddInputArgument(newArg)
 		}
 	}
 
 	if len(s.Options) > 0 {
 		for _, opt := range s.Options {
-			newOpt := option.New(opt.Name, opt.Value)
+			s.AddInputOption(opt.inputOption())
+		}
+	}
+
+	for _, group := range s.OptionGroups {
+		s.input.Definition().AddOptionGroup(group)
+	}
+
+	if s.Bind != nil {
+		s.addBoundDefinition()
+	}
+
+	if !s.AddDefaultOpts {
+		s.addDefaultOptions()
+	}
+
+	return s
+}
+
+// BindTo declare the arguments and options from the tagged fields of the struct pointer,
+// the fields receive the parsed values before the runner is called
+func (s *Script) BindTo(target interface{}) *Script {
+	s.Bind = target
+
+	if s.definitionParsed {
+		s.addBoundDefinition()
+	}
+
+	return s
+}
+
+// addBoundDefinition add the arguments and options declared by the bound struct
+func (s *Script) addBoundDefinition() {
+	def := input.StructDefinition(s.Bind)
 
-			if opt.Shortcut != "" {
-				newOpt.SetShortcut(opt.Shortcut)
+	for _, key := range def.ArgumentsOrder() {
+		s.AddInputArgument(def.Argument(key))
+	}
+
+	for _, key := range def.OptionsOrder() {
+		s.AddInputOption(def.Option(key))
+	}
+}
+
+// bindInput write the parsed values into the bound struct
+func (s *Script) bindInput() (err error) {
+	if s.Bind == nil {
+		return nil
+	}
+
+	defer s.handleParsingException(&err)
+
+	input.BindStruct(s.input, s.Bind)
+
+	return nil
+}
+
+// inheritOptions add the options of every parent script, the command persistent options
+// and the configuration file option not already defined
+func (s *Script) inheritOptions() (err error) {
+	// e.g. a shortcut declared by the script and one of its parents
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = toError(recovered)
+		}
+	}()
+
+	for parent := s.parent; parent != nil; parent = parent.parent {
+		for _, opt := range parent.definedOptions() {
+			if !s.input.Definition().HasOption(opt.Name()) {
+				s.AddInputOption(opt)
 			}
+		}
+	}
+
+	for _, opt := range s.persistentOptions {
+		if !s.input.Definition().HasOption(opt.Name) {
+			s.AddInputOption(opt.inputOption())
+		}
+	}
 
-			if opt.Description != "" {
-				newOpt.SetDescription(opt.Description)
+	if file := s.configFile(); file != nil && !s.input.Definition().HasOption(file.optionName()) {
+		s.AddInputOption(file.inputOption())
+	}
+
+	return nil
+}
+
+// syntax return the command line syntax of the script, its parents or the command (nil for the default syntax)
+func (s *Script) syntax() *definition.Syntax {
+	for script := s; script != nil; script = script.parent {
+		if script.Syntax != nil {
+			return script.Syntax
+		}
+	}
+
+	return s.persistentSyntax
+}
+
+// definedOptions return the script options, from its definition when already parsed
+func (s *Script) definedOptions() []*option.InputOption {
+	var options []*option.InputOption
+
+	if s.input == nil {
+		for _, opt := range s.Options {
+			options = append(options, opt.inputOption())
+		}
+
+		return options
+	}
+
+	for _, key := range s.input.Definition().OptionsOrder() {
+		options = append(options, s.input.Definition().Option(key))
+	}
+
+	return options
+}
+
+// SubScript return a nested script by name
+func (s *Script) SubScript(name string) *Script {
+	for _, sub := range s.Scripts {
+		if sub.Name == name {
+			return sub
+		}
+
+		for _, alias := range sub.Aliases {
+			if alias == name {
+				return sub
 			}
+		}
+	}
+
+	return nil
+}
 
-			if opt.DefaultValue != "" {
-				newOpt.SetDefault(opt.DefaultValue)
+// resolveSubScript walk nested scripts matching the leading tokens, returning the deepest one and the remaining tokens,
+// the options defined at each level may be given between the script names (e.g. "db --dsn=pgsql:// migrate")
+func (s *Script) resolveSubScript(tokens []string) (*Script, []string) {
+	current := s
+	var options []string
+
+	for len(tokens) > 0 {
+		token := tokens[0]
+
+		if token != "" && token[0] == '-' && len(current.Scripts) > 0 {
+			defined, separateValue := inspectOptionToken(current.inputDefinition(), token)
+
+			if !defined {
+				break
 			}
 
-			if len(opt.DefaultValues) > 0 {
-				newOpt.SetDefaults(opt.DefaultValues)
+			count := 1
+
+			if separateValue && len(tokens) > 1 && (tokens[1] == "" || tokens[1][0] != '-') {
+				count = 2
 			}
 
-			s.AddInputOption(newOpt)
+			options = append(options, tokens[:count]...)
+			tokens = tokens[count:]
+			continue
 		}
+
+		sub := current.SubScript(token)
+
+		if sub == nil {
+			break
+		}
+
+		sub.parent = current
+		sub.SetParentScriptName(current.fullName())
+		sub.persistentOptions = current.persistentOptions
+		sub.persistentConfig = current.persistentConfig
+		sub.persistentSyntax = current.persistentSyntax
+
+		current = sub
+		tokens = tokens[1:]
 	}
 
-	if !s.AddDefaultOpts {
-		s.addDefaultOptions()
+	return current, append(options, tokens...)
+}
+
+// inspectOptionToken return whether the option token (e.g. --env, --env=prod, -e, -ve) is defined
+// and whether it takes the next token as value (e.g. --env prod, -e prod), as ArgvInput parses it
+func inspectOptionToken(def *definition.InputDefinition, token string) (defined bool, separateValue bool) {
+	if len(token) < 2 || token[0] != '-' {
+		return false, false
 	}
 
-	return s
+	var opt *option.InputOption
+
+	if strings.HasPrefix(token, "--") {
+		name := token[2:]
+
+		if pos := strings.Index(name, "="); pos != -1 {
+			name = name[:pos]
+
+			return def.HasOption(name) || isNegation(def, name), false
+		}
+
+		if isNegation(def, name) {
+			return true, false
+		}
+
+		if !def.HasOption(name) {
+			return false, false
+		}
+
+		opt = def.Option(name)
+	} else if name := token[1:]; len(name) == 1 || def.HasShortcut(name) && def.FindOptionForShortcut(name).IsValueNone() {
+		if !def.HasShortcut(name) {
+			return false, false
+		}
+
+		opt = def.FindOptionForShortcut(name)
+	} else {
+		// the last option of a set may take the next token (e.g. -ve prod)
+		for index := 0; index < len(name); index++ {
+			if !def.HasShortcut(name[index : index+1]) {
+				return false, false
+			}
+
+			opt = def.FindOptionForShortcut(name[index : index+1])
+
+			if opt.IsAcceptValue() && index < len(name)-1 {
+				// value given with no space (e.g. -eprod)
+				return true, false
+			}
+
+			if opt.IsAcceptValue() {
+				break
+			}
+		}
+	}
+
+	syntax := def.Syntax()
+
+	return true, !opt.IsCount() && (opt.IsValueRequired() && syntax.SeparateValues || opt.IsValueOptional() && syntax.SeparateOptionalValues)
+}
+
+// isNegation return true when the name is the negation of a negatable option (e.g. no-cache)
+func isNegation(def *definition.InputDefinition, name string) bool {
+	negated := strings.TrimPrefix(name, "no-")
+
+	return negated != name && !def.HasOption(name) && def.HasOption(negated) && def.Option(negated).IsNegatable()
+}
+
+// fullName return the script name prefixed by its parents names
+func (s *Script) fullName() string {
+	if s.parentScriptName == "" {
+		return s.Name
+	}
+
+	return s.parentScriptName + " " + s.Name
 }
 
-func (s *Script) parseInput() *Script {
+// syncAccessors apply Input and Output replaced after the script construction
+func (s *Script) syncAccessors() {
+	if s.Input != nil && s.Input != s.input {
+		// keep arguments and options already defined
+		*s.Input.Definition() = *s.input.Definition()
+		s.input = s.Input
+	}
+
+	if s.Output != nil && s.Output != s.output {
+		// clone the formatter to retrieve styles and avoid state change
+		format := *s.Output.Formatter()
+
+		s.output = s.Output
+		s.bufferedOutput = *output.NewBufferedOutput(false, &format)
+		s.questionHelper = nil
+	}
+}
+
+func (s *Script) parseInput() (err error) {
+	defer s.handleParsingException(&err)
+
 	if s.inputParsed {
 		panic(errors.New("argv is already parsed"))
 	}
 
-	defer s.handleParsingException()
-
 	s.input.Parse()
 	s.inputParsed = true
 
-	return s
+	return nil
 }
 
-func (s *Script) validateInput() *Script {
+func (s *Script) validateInput() (err error) {
 	if !s.inputParsed {
 		panic(errors.New("cannot validate unparsed input"))
 	}
 
-	defer s.handleParsingException()
+	defer s.handleParsingException(&err)
 
 	s.input.Validate()
 
-	return s
+	return nil
 }
 
 func (s *Script) findOutputVerbosity() *Script {
-	level := verbosity.Normal
+	level := verbosityLevel(s.input)
 
-	if s.input.Option("quiet") == option.Defined {
-		level = verbosity.Quiet
-	} else if s.input.Option("verbose") == option.Defined {
-		lvl := s.input.Option("verbose")
-		if lvl == "vv" {
-			level = verbosity.Debug
-		} else if lvl == "v" {
-			level = verbosity.VeryVerbose
-		}
+	// a called script keeps the verbosity of its caller unless --quiet or --verbose is given
+	if s.caller != nil && level == verbosity.Normal {
+		level = s.caller.output.Verbosity()
 	}
 
 	s.output.SetVerbosity(level)
@@ -313,17 +858,73 @@ func (s *Script) findOutputVerbosity() *Script {
 	return s
 }
 
-func (s *Script) handleParsingException() {
-	err := recover()
+func (s *Script) findOutputDecoration() *Script {
+	applyDecoration(s.input, s.output)
 
-	if err == nil {
+	return s
+}
+
+// applyDecoration force or disable the decoration of the output and its error output with --ansi or --no-ansi
+func applyDecoration(in input.InputInterface, out output.OutputInterface) {
+	if !in.Definition().HasOption("ansi") {
+		return
+	}
+
+	ansi := in.OptionNegatable("ansi")
+
+	if ansi == nil {
+		return
+	}
+
+	out.SetDecorated(*ansi)
+
+	if console, ok := out.(output.ConsoleOutputInterface); ok {
+		console.ErrorOutput().SetDecorated(*ansi)
+	}
+}
+
+// verbosityLevel return the verbosity given by --quiet and the number of --verbose (-v, -vv or -vvv)
+func verbosityLevel(in input.InputInterface) verbosity.Level {
+	if in.OptionBool("quiet") {
+		return verbosity.Quiet
+	}
+
+	switch count := in.OptionCount("verbose"); {
+	case count >= 3:
+		return verbosity.Debug
+	case count == 2:
+		return verbosity.VeryVerbose
+	case count == 1:
+		return verbosity.Verbose
+	}
+
+	return verbosity.Normal
+}
+
+// handleParsingException recover parsing panics, display them with the usage and store them into err
+func (s *Script) handleParsingException(err *error) {
+	recovered := recover()
+
+	if recovered == nil {
 		// nothing append, continue
 		return
 	}
 
-	s.PrintError(fmt.Sprintf("%s", err))
+	s.printParsingException(recovered)
+
+	*err = toError(recovered)
+}
+
+// printParsingException display the error followed by the script usage
+func (s *Script) printParsingException(recovered interface{}) {
+	errorStyle := s.ErrorStyle()
+	errorStyle.PrintError(fmt.Sprintf("%s", recovered))
 
 	args := os.Args[0]
+
+	if s.parentScriptName != "" {
+		args = s.fullName()
+	}
 	synopsis := s.input.Definition().Synopsis(false)
 
 	usage := fmt.Sprintf(
@@ -332,11 +933,18 @@ func (s *Script) handleParsingException() {
 		formatter.Escape(synopsis),
 	)
 
-	s.output.Println(usage)
+	errorStyle.output.Println(usage)
+}
 
-	os.Exit(2)
+// handledException a runtime panic already displayed by HandleRuntimeException, returned as an error by Execute
+// (always ExitInvalid, the exit code of HandleRuntimeException whatever the entry point)
+type handledException struct {
+	code ExitCode
+	err  error
 }
 
+// HandleRuntimeException display the recovered panic and exit the process,
+// within Execute the exit code and the error are returned instead
 func (s *Script) HandleRuntimeException() {
 	err := recover()
 
@@ -345,32 +953,62 @@ func (s *Script) HandleRuntimeException() {
 		return
 	}
 
+	s.exit(s.displayException(err))
+}
+
+// displayException display the recovered panic, typed accessors conversion errors are displayed as invalid input
+func (s *Script) displayException(err interface{}) handledException {
+	if invalid, ok := err.(*input.InvalidValueError); ok {
+		s.printParsingException(invalid)
+		return handledException{code: ExitInvalid, err: invalid}
+	}
+
+	s.printRuntimeException(err)
+
+	return handledException{code: ExitInvalid, err: toError(err)}
+}
+
+// exit the process with the handled exception code, or hand it over to Execute when the script is running within it
+func (s *Script) exit(handled handledException) {
+	if s.executing {
+		panic(handled)
+	}
+
+	os.Exit(int(handled.code))
+}
+
+func (s *Script) printRuntimeException(err interface{}) {
 	msg := fmt.Sprintf("%s", err)
 	full := fmt.Sprintf("%+v", err)
 
 	traces := strings.TrimPrefix(full, msg)
 	traces = strings.Replace(traces, "\n\t", "() at ", -1)
 
-	s.PrintError(msg)
+	errorStyle := s.ErrorStyle()
+	errorStyle.PrintError(msg)
 
-	s.output.Print("<comment>Exception trace:</comment>")
+	errorStyle.output.Print("<comment>Exception trace:</comment>")
 	for _, trace := range strings.Split(traces, "\n") {
-		s.output.Println(
+		errorStyle.output.Println(
 			fmt.Sprintf(
 				" %s",
 				formatter.Escape(trace),
 			),
 		)
 	}
-
-	os.Exit(2)
 }
 
-func (s *Script) handleHelpCall() {
+func (s *Script) handleHelpCall() bool {
 	if s.input.Option("help") == option.Undefined {
-		return
+		return false
 	}
 
+	s.showHelp()
+
+	return true
+}
+
+func (s *Script) showHelp() {
 	if s.Description != "" {
 		s.PrintText("<comment>Description:</comment>")
 		s.PrintText(s.Description)
@@ -385,12 +1023,17 @@ func (s *Script) handleHelpCall() {
 	cmdName := filepath.Base(os.Args[0])
 
 	if s.parentScriptName != "" {
-		cmdName = s.parentScriptName + " " + cmdName
+		cmdName = s.fullName()
+	}
+
+	if len(s.Scripts) > 0 {
+		cmdName += " [command]"
 	}
 
+	decorated := s.output.IsDecorated()
 	s.output.SetDecorated(false)
 	s.PrintText(fmt.Sprintf(" %s <info>%s</info>", cmdName, synopsis))
-	s.output.SetDecorated(true)
+	s.output.SetDecorated(decorated)
 
 	render := table.
 		NewRender(s.output).
@@ -414,12 +1057,28 @@ func (s *Script) handleHelpCall() {
 			Render()
 	}
 
-	os.Exit(int(ExitSuccess))
+	if len(s.input.Definition().OptionGroups()) > 0 {
+		s.PrintNewLine(1)
+		s.PrintText("<comment>Option groups:</comment>")
+
+		for _, group := range s.input.Definition().OptionGroups() {
+			s.PrintText(fmt.Sprintf("  <info>%s</info>", group.Describe()))
+		}
+	}
+
+	if len(s.Scripts) > 0 {
+		s.PrintNewLine(1)
+		s.PrintText("<comment>Available commands:</comment>")
+
+		render.
+			SetContent(s.createScriptsTable()).
+			Render()
+	}
 }
 
-func (s *Script) handleVersionCall() {
+func (s *Script) handleVersionCall() bool {
 	if s.input.Option("version") == option.Undefined {
-		return
+		return false
 	}
 
 	// deprecated but still supported and prior to other options
@@ -451,7 +1110,8 @@ func (s *Script) handleVersionCall() {
 	}
 
 	s.PrintText(tagLine)
-	os.Exit(int(ExitSuccess))
+
+	return true
 }
 
 func (s *Script) createArgsTable() *table.Table {
@@ -480,6 +1140,10 @@ func (s *Script) createArgsTable() *table.Table {
 
 		desc := arg.Description()
 
+		if arg.Constraint() != nil {
+			desc += fmt.Sprintf(" <comment>[%s]</comment>", formatter.Escape(arg.Constraint().Describe()))
+		}
+
 		if !arg.IsList() && arg.Default() != "" {
 			desc += fmt.Sprintf(
 				" <comment>[default: \"%s\"]</comment>",
@@ -494,6 +1158,10 @@ func (s *Script) createArgsTable() *table.Table {
 			)
 		}
 
+		if arg.Env() != "" {
+			desc += fmt.Sprintf(" <comment>[env: %s]</comment>", arg.Env())
+		}
+
 		argTab.
 			AddRowFromString([]string{
 				name, flagLine, desc,
@@ -504,10 +1172,15 @@ func (s *Script) createArgsTable() *table.Table {
 }
 
 func (s *Script) createOptsTable() *table.Table {
+	return createOptionsTable(s.input.Definition())
+}
+
+// createOptionsTable list the options of a definition, shared by the script and the command help
+func createOptionsTable(def *definition.InputDefinition) *table.Table {
 	optTab := table.NewTable()
 
-	for _, optKey := range s.input.Definition().OptionsOrder() {
-		opt := s.input.Definition().Option(optKey)
+	for _, optKey := range def.OptionsOrder() {
+		opt := def.Option(optKey)
 		shortcut := ""
 
 		if opt.Shortcut() != "" {
@@ -517,13 +1190,24 @@ func (s *Script) createOptsTable() *table.Table {
 			)
 		}
 
+		negation := ""
+
+		if opt.IsNegatable() {
+			negation = "[no-]"
+		}
+
 		name := fmt.Sprintf(
-			" <info>--%s</info>",
+			" <info>--%s%s</info>",
+			negation,
 			opt.Name(),
 		)
 
 		desc := opt.Description()
 
+		if opt.Constraint() != nil {
+			desc += fmt.Sprintf(" <comment>[%s]</comment>", formatter.Escape(opt.Constraint().Describe()))
+		}
+
 		if !opt.IsList() && opt.Default() != "" {
 			desc += fmt.Sprintf(
 				" <comment>[default: \"%s\"]</comment>",
@@ -538,6 +1222,10 @@ func (s *Script) createOptsTable() *table.Table {
 			)
 		}
 
+		if opt.Env() != "" {
+			desc += fmt.Sprintf(" <comment>[env: %s]</comment>", opt.Env())
+		}
+
 		optTab.
 			AddRowFromString([]string{
 				shortcut, name, desc,
@@ -547,6 +1235,50 @@ func (s *Script) createOptsTable() *table.Table {
 	return optTab
 }
 
+func (s *Script) createScriptsTable() *table.Table {
+	scriptTab := table.NewTable()
+
+	for _, sub := range s.Scripts {
+		if sub.Hidden {
+			continue
+		}
+
+		name := fmt.Sprintf(
+			" <info>%s</info>",
+			sub.Name,
+		)
+
+		scriptTab.
+			AddRowFromString([]string{
+				name, sub.describe(),
+			})
+	}
+
+	return scriptTab
+}
+
+// describe return the script description prefixed by its aliases
+func (s *Script) describe() string {
+	if len(s.Aliases) == 0 {
+		return s.Description
+	}
+
+	return fmt.Sprintf(
+		"<comment>[%s]</comment> %s",
+		strings.Join(s.Aliases, "|"),
+		s.Description,
+	)
+}
+
 func (s *Script) SetParentScriptName(name string) {
 	s.parentScriptName = name
 }
+
+// toError convert a recovered value into an error
+func toError(recovered interface{}) error {
+	if err, ok := recovered.(error); ok {
+		return err
+	}
+
+	return errors.New(fmt.Sprintf("%v", recovered))
+}
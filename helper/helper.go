@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"github.com/DrSmithFr/go-console/formatter"
 	"regexp"
+	"sort"
+	"strings"
 	"syscall"
 	"unicode"
 	"unicode/utf8"
@@ -28,7 +30,7 @@ func RemoveDecoration(outputFormatter formatter.OutputFormatterInterface, messag
 	noTag := outputFormatter.Format(message)
 
 	// remove already formatted characters
-	regex := regexp.MustCompile("\\033\\[[^m]*m/")
+	regex := regexp.MustCompile("\\033\\[[^m]*m")
 	noDecoration := regex.ReplaceAllString(noTag, "")
 
 	outputFormatter.SetDecorated(wasDecorated)
@@ -300,3 +302,112 @@ var syscallMap = map[any]int{
 func Syscall(val any) int {
 	return syscallMap[val]
 }
+
+// EditDistance compute the edit distance between two strings (insertion, deletion, substitution and transposition)
+func EditDistance(a string, b string) int {
+	source := []rune(a)
+	target := []rune(b)
+
+	matrix := make([][]int, len(source)+1)
+
+	for i := range matrix {
+		matrix[i] = make([]int, len(target)+1)
+		matrix[i][0] = i
+	}
+
+	for j := range matrix[0] {
+		matrix[0][j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+
+			matrix[i][j] = MinInt([]int{
+				matrix[i-1][j] + 1,
+				matrix[i][j-1] + 1,
+				matrix[i-1][j-1] + cost,
+			})
+
+			if i > 1 && j > 1 && source[i-1] == target[j-2] && source[i-2] == target[j-1] {
+				matrix[i][j] = MinInt([]int{matrix[i][j], matrix[i-2][j-2] + 1})
+			}
+		}
+	}
+
+	return matrix[len(source)][len(target)]
+}
+
+// MinInt returns the smallest value of a non-empty list
+func MinInt(list []int) int {
+	min := list[0]
+
+	for _, val := range list {
+		if val < min {
+			min = val
+		}
+	}
+
+	return min
+}
+
+// Alternatives returns the candidates close to the search (by edit distance or inclusion), closest first
+func Alternatives(search string, candidates []string) []string {
+	distances := map[string]int{}
+	threshold := Strlen(search) / 3
+
+	for _, candidate := range candidates {
+		distance := EditDistance(search, candidate)
+
+		if distance <= threshold || strings.Contains(candidate, search) {
+			distances[candidate] = distance
+		}
+	}
+
+	alternatives := []string{}
+
+	for candidate := range distances {
+		alternatives = append(alternatives, candidate)
+	}
+
+	sort.Slice(alternatives, func(i, j int) bool {
+		if distances[alternatives[i]] == distances[alternatives[j]] {
+			return alternatives[i] < alternatives[j]
+		}
+
+		return distances[alternatives[i]] < distances[alternatives[j]]
+	})
+
+	return alternatives
+}
+
+// DidYouMean format alternatives as a suggestion, empty when there is no alternative
+func DidYouMean(alternatives []string) string {
+	if len(alternatives) == 0 {
+		return ""
+	}
+
+	if len(alternatives) == 1 {
+		return fmt.Sprintf("Did you mean '%s'?", alternatives[0])
+	}
+
+	return fmt.Sprintf("Did you mean one of these? '%s'", strings.Join(alternatives, "', '"))
+}
+
+// FormatMemory formats a number of bytes into a short string (e.g. "12.5 MiB")
+func FormatMemory(bytes uint64) string {
+	switch {
+	case bytes >= 1024*1024*1024:
+		return fmt.Sprintf("%.1f GiB", float64(bytes)/1024/1024/1024)
+	case bytes >= 1024*1024:
+		return fmt.Sprintf("%.1f MiB", float64(bytes)/1024/1024)
+	case bytes >= 1024:
+		return fmt.Sprintf("%d KiB", bytes/1024)
+	}
+
+	return fmt.Sprintf("%d B", bytes)
+}
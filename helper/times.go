@@ -115,3 +115,44 @@ func CustomRelTime(a, b time.Time, albl, blbl string, magnitudes []RelTimeMagnit
 	}
 	return fmt.Sprintf(mag.Format, args...)
 }
+
+// A DurationMagnitude is the point from which a duration is displayed with Format,
+// the quantity is the duration divided by DivBy (no quantity when DivBy is 0)
+type DurationMagnitude struct {
+	D      time.Duration
+	Format string
+	DivBy  time.Duration
+}
+
+var durationMagnitudes = []DurationMagnitude{
+	{0, "< 1 sec", 0},
+	{time.Second, "1 sec", 0},
+	{2 * time.Second, "%d secs", time.Second},
+	{time.Minute, "1 min", 0},
+	{2 * time.Minute, "%d mins", time.Minute},
+	{time.Hour, "1 hr", 0},
+	{2 * time.Hour, "%d hrs", time.Hour},
+	{Day, "1 day", 0},
+	{2 * Day, "%d days", Day},
+}
+
+// FormatDuration formats a duration into a short string.
+//
+// FormatDuration(90 * time.Second) -> "1 min"
+func FormatDuration(d time.Duration) string {
+	n := sort.Search(len(durationMagnitudes), func(i int) bool {
+		return durationMagnitudes[i].D > d
+	}) - 1
+
+	if n < 0 {
+		n = 0
+	}
+
+	mag := durationMagnitudes[n]
+
+	if mag.DivBy == 0 {
+		return mag.Format
+	}
+
+	return fmt.Sprintf(mag.Format, d/mag.DivBy)
+}
@@ -3,6 +3,8 @@ package argument
 import (
 	"errors"
 	"fmt"
+	"github.com/DrSmithFr/go-console/input/completion"
+	"github.com/DrSmithFr/go-console/input/constraint"
 )
 
 const (
@@ -39,6 +41,9 @@ type InputArgument struct {
 	defaultValue  string
 	defaultValues []string
 	description   string
+	env           string
+	constraint    *constraint.Constraint
+	completion    completion.Provider
 }
 
 // Returns the argument name.
@@ -113,3 +118,53 @@ func (a *InputArgument) SetDescription(desc string) *InputArgument {
 	a.description = desc
 	return a
 }
+
+// Sets the provider of candidate values for shell completion and prompts
+func (a *InputArgument) SetCompletion(provider completion.Provider) *InputArgument {
+	a.completion = provider
+	return a
+}
+
+// Returns the provider of candidate values (nil when not defined)
+func (a *InputArgument) Completion() completion.Provider {
+	return a.completion
+}
+
+// Returns the candidate values for the partially typed value
+func (a *InputArgument) Complete(value string, input completion.Input) []string {
+	if a.completion == nil && a.constraint != nil && len(a.constraint.AllowedValues()) > 0 {
+		return completion.Values(a.constraint.AllowedValues()...)(value, input)
+	}
+
+	if a.completion == nil {
+		return []string{}
+	}
+
+	return a.completion(value, input)
+}
+
+// Sets the environment variable used when the argument is not given on the command line.
+func (a *InputArgument) SetEnv(name string) *InputArgument {
+	a.env = name
+	return a
+}
+
+// Returns the environment variable name (empty when not defined).
+func (a *InputArgument) Env() string {
+	return a.env
+}
+
+// Sets the value type and constraints, checked when the input is validated.
+func (a *InputArgument) SetConstraint(c *constraint.Constraint) *InputArgument {
+	if c != nil {
+		c.Check()
+	}
+
+	a.constraint = c
+	return a
+}
+
+// Returns the value type and constraints (nil when not defined).
+func (a *InputArgument) Constraint() *constraint.Constraint {
+	return a.constraint
+}
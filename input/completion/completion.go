@@ -0,0 +1,32 @@
+package completion
+
+import "strings"
+
+// Input gives read access to the input parsed so far
+type Input interface {
+	HasArgument(name string) bool
+	Argument(name string) string
+	ArgumentList(name string) []string
+
+	HasOption(name string) bool
+	Option(name string) string
+	OptionList(name string) []string
+}
+
+// Provider returns the candidate values for the partially typed value
+type Provider func(value string, input Input) []string
+
+// Values create a provider suggesting the given values starting with the typed value
+func Values(values ...string) Provider {
+	return func(value string, input Input) []string {
+		candidates := []string{}
+
+		for _, candidate := range values {
+			if strings.HasPrefix(candidate, value) {
+				candidates = append(candidates, candidate)
+			}
+		}
+
+		return candidates
+	}
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseDotenv decode a .env file (KEY=value lines, optionally prefixed by export)
+func parseDotenv(content []byte) (map[string]interface{}, error) {
+	data := map[string]interface{}{}
+
+	for number, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		pos := strings.Index(line, "=")
+
+		if pos == -1 {
+			return nil, errors.New(fmt.Sprintf("line %d: expected KEY=value", number+1))
+		}
+
+		key := strings.TrimSpace(line[:pos])
+		value := strings.TrimSpace(line[pos+1:])
+
+		switch {
+		case strings.HasPrefix(value, "\""):
+			unquoted, err := strconv.Unquote(value)
+
+			if err != nil {
+				return nil, errors.New(fmt.Sprintf("line %d: invalid string %s", number+1, value))
+			}
+
+			value = unquoted
+		case strings.HasPrefix(value, "'") && len(value) > 1 && strings.HasSuffix(value, "'"):
+			value = value[1 : len(value)-1]
+		default:
+			if comment := strings.Index(value, " #"); comment != -1 {
+				value = strings.TrimSpace(value[:comment])
+			}
+		}
+
+		data[key] = value
+	}
+
+	return data, nil
+}
@@ -0,0 +1,14 @@
+package config
+
+import "encoding/json"
+
+// parseJson decode a JSON object
+func parseJson(content []byte) (map[string]interface{}, error) {
+	data := map[string]interface{}{}
+
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
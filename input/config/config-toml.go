@@ -0,0 +1,50 @@
+package config
+
+import (
+	"github.com/BurntSushi/toml"
+	"time"
+)
+
+// layouts of the TOML dates and times without offset, decoded within a location of that name
+var tomlLocalLayouts = map[string]string{
+	"datetime-local": "2006-01-02T15:04:05.999999999",
+	"date-local":     "2006-01-02",
+	"time-local":     "15:04:05.999999999",
+}
+
+// parseToml decode a TOML document
+func parseToml(content []byte) (map[string]interface{}, error) {
+	data := map[string]interface{}{}
+
+	if _, err := toml.Decode(string(content), &data); err != nil {
+		return nil, err
+	}
+
+	localDates(data)
+
+	return data, nil
+}
+
+// localDates keep the dates and times without offset as written (e.g. 1979-05-27), instead of a date in the local timezone
+func localDates(data map[string]interface{}) {
+	for key, value := range data {
+		data[key] = localDate(value)
+	}
+}
+
+func localDate(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case time.Time:
+		if layout, ok := tomlLocalLayouts[typed.Location().String()]; ok {
+			return typed.Format(layout)
+		}
+	case map[string]interface{}:
+		localDates(typed)
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = localDate(item)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,14 @@
+package config
+
+import "gopkg.in/yaml.v3"
+
+// parseYaml decode a YAML mapping
+func parseYaml(content []byte) (map[string]interface{}, error) {
+	data := map[string]interface{}{}
+
+	if err := yaml.Unmarshal(content, &data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -0,0 +1,252 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Values holds the values read from a configuration file, indexed by argument or option name
+type Values struct {
+	path   string
+	format string
+	values map[string][]string
+}
+
+// Load read the configuration file, the format is guessed from the file extension (json, yaml, yml, toml or env)
+func Load(path string) (*Values, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var data map[string]interface{}
+
+	kind := format(path)
+
+	switch kind {
+	case "json":
+		data, err = parseJson(content)
+	case "yaml", "yml":
+		data, err = parseYaml(content)
+	case "toml":
+		data, err = parseToml(content)
+	case "env":
+		data, err = parseDotenv(content)
+	default:
+		return nil, errors.New(fmt.Sprintf("the configuration file '%s' has an unsupported format", path))
+	}
+
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to parse the configuration file '%s': %s", path, err))
+	}
+
+	values, err := FromMap(data)
+
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to parse the configuration file '%s': %s", path, err))
+	}
+
+	values.path = path
+	values.format = kind
+
+	return values, nil
+}
+
+// FromMap create the values from already decoded data (e.g. a format read by the application itself)
+func FromMap(data map[string]interface{}) (*Values, error) {
+	values := &Values{
+		values: map[string][]string{},
+	}
+
+	if err := values.flatten("", data); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// Find return the first existing file (empty when none exists)
+func Find(paths ...string) string {
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+
+	return ""
+}
+
+// Path return the path of the loaded file
+func (v *Values) Path() string {
+	if v == nil {
+		return ""
+	}
+
+	return v.path
+}
+
+// Keys return the names of all the defined values
+func (v *Values) Keys() []string {
+	keys := []string{}
+
+	if v == nil {
+		return keys
+	}
+
+	for key := range v.values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Lookup return the values of the given argument or option name
+// the name is also looked up in snake case and upper snake case (e.g. db-dsn, db_dsn and DB_DSN)
+func (v *Values) Lookup(name string) ([]string, bool) {
+	if v == nil {
+		return nil, false
+	}
+
+	snake := strings.ReplaceAll(name, "-", "_")
+
+	for _, key := range []string{name, snake, strings.ToUpper(snake)} {
+		if values, ok := v.values[key]; ok {
+			return values, true
+		}
+	}
+
+	return nil, false
+}
+
+// Value return the value of the given argument or option name (the last one for lists)
+func (v *Values) Value(name string) (string, bool) {
+	values, ok := v.Lookup(name)
+
+	if !ok || len(values) == 0 {
+		return "", ok
+	}
+
+	return values[len(values)-1], true
+}
+
+// List return the values of the given list argument or option name
+// .env files hold comma separated lists, as environment variables do
+func (v *Values) List(name string) ([]string, bool) {
+	values, ok := v.Lookup(name)
+
+	if !ok || v.format != "env" {
+		return values, ok
+	}
+
+	list := []string{}
+
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+
+	return list, true
+}
+
+// flatten store the decoded data, nested keys are joined with a dash (e.g. db: {dsn: x} gives db-dsn)
+func (v *Values) flatten(prefix string, data map[string]interface{}) error {
+	for key, value := range data {
+		if prefix != "" {
+			key = prefix + "-" + key
+		}
+
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			if err := v.flatten(key, typed); err != nil {
+				return err
+			}
+		case []interface{}:
+			list := []string{}
+
+			for _, item := range typed {
+				str, err := scalar(key, item)
+
+				if err != nil {
+					return err
+				}
+
+				list = append(list, str)
+			}
+
+			v.values[key] = list
+		default:
+			str, err := scalar(key, typed)
+
+			if err != nil {
+				return err
+			}
+
+			v.values[key] = []string{str}
+		}
+	}
+
+	return nil
+}
+
+// scalar convert a decoded value into its string representation
+func scalar(key string, value interface{}) (string, error) {
+	switch typed := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return typed, nil
+	case bool:
+		return strconv.FormatBool(typed), nil
+	case int:
+		return strconv.Itoa(typed), nil
+	case int8:
+		return strconv.FormatInt(int64(typed), 10), nil
+	case int16:
+		return strconv.FormatInt(int64(typed), 10), nil
+	case int32:
+		return strconv.FormatInt(int64(typed), 10), nil
+	case int64:
+		return strconv.FormatInt(typed, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(typed), 10), nil
+	case uint8:
+		return strconv.FormatUint(uint64(typed), 10), nil
+	case uint16:
+		return strconv.FormatUint(uint64(typed), 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(typed), 10), nil
+	case uint64:
+		return strconv.FormatUint(typed, 10), nil
+	case float32:
+		return strconv.FormatFloat(float64(typed), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64), nil
+	case time.Time:
+		return typed.Format(time.RFC3339), nil
+	}
+
+	return "", errors.New(fmt.Sprintf("unsupported value for '%s'", key))
+}
+
+// format return the file format from its extension (.env files have no extension)
+func format(path string) string {
+	base := strings.ToLower(filepath.Base(path))
+
+	if base == ".env" || strings.HasPrefix(base, ".env.") {
+		return "env"
+	}
+
+	return strings.TrimPrefix(filepath.Ext(base), ".")
+}
@@ -0,0 +1,261 @@
+package constraint
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// value types
+const (
+	TypeString   = "string"
+	TypeInt      = "int"
+	TypeFloat    = "float"
+	TypeBool     = "bool"
+	TypeDuration = "duration"
+	TypeEnum     = "enum"
+	TypePath     = "path"
+	TypeUrl      = "url"
+)
+
+// Constraint describes the type and the rules a value must follow
+type Constraint struct {
+	kind      string
+	choices   []string
+	min       *float64
+	max       *float64
+	pattern   *regexp.Regexp
+	mustExist bool
+}
+
+// New create a constraint for the given value type
+func New(kind string) *Constraint {
+	switch kind {
+	case TypeString, TypeInt, TypeFloat, TypeBool, TypeDuration, TypeEnum, TypePath, TypeUrl:
+	default:
+		panic(errors.New(fmt.Sprintf("value type '%s' is not valid", kind)))
+	}
+
+	return &Constraint{kind: kind}
+}
+
+// String any string
+func String() *Constraint {
+	return New(TypeString)
+}
+
+// Int an integer
+func Int() *Constraint {
+	return New(TypeInt)
+}
+
+// Float a number
+func Float() *Constraint {
+	return New(TypeFloat)
+}
+
+// Bool a boolean (true, false, yes, no, on, off, 1 or 0)
+func Bool() *Constraint {
+	return New(TypeBool)
+}
+
+// Duration a duration (e.g. 1h30m)
+func Duration() *Constraint {
+	return New(TypeDuration)
+}
+
+// Enum one of the given values (at least one)
+func Enum(choices ...string) *Constraint {
+	constraint := New(TypeEnum).Choices(choices...)
+	constraint.Check()
+
+	return constraint
+}
+
+// Path a file path
+func Path() *Constraint {
+	return New(TypePath)
+}
+
+// Url an absolute URL
+func Url() *Constraint {
+	return New(TypeUrl)
+}
+
+// Returns the value type.
+func (c *Constraint) Type() string {
+	return c.kind
+}
+
+// Choices restrict the value to the given ones.
+func (c *Constraint) Choices(choices ...string) *Constraint {
+	c.choices = choices
+	return c
+}
+
+// Check panics when the constraint cannot be used by a definition (e.g. an enum without choices).
+func (c *Constraint) Check() {
+	if c.kind == TypeEnum && len(c.choices) == 0 {
+		panic(errors.New("an enum constraint needs at least one choice"))
+	}
+}
+
+// Returns the allowed values (empty when any value is allowed).
+func (c *Constraint) AllowedValues() []string {
+	return c.choices
+}
+
+// Min set the minimum of int and float values.
+func (c *Constraint) Min(min float64) *Constraint {
+	c.min = &min
+	return c
+}
+
+// Max set the maximum of int and float values.
+func (c *Constraint) Max(max float64) *Constraint {
+	c.max = &max
+	return c
+}
+
+// Range set the minimum and the maximum of int and float values.
+func (c *Constraint) Range(min float64, max float64) *Constraint {
+	return c.Min(min).Max(max)
+}
+
+// Pattern restrict the value to the given regular expression.
+func (c *Constraint) Pattern(expr string) *Constraint {
+	c.pattern = regexp.MustCompile(expr)
+	return c
+}
+
+// MustExist restrict path values to existing files or directories.
+func (c *Constraint) MustExist() *Constraint {
+	if c.kind != TypePath {
+		panic(errors.New("MustExist() can only be used with path values"))
+	}
+
+	c.mustExist = true
+	return c
+}
+
+// Validate check the value, the error describes the expected value (e.g. "an integer")
+func (c *Constraint) Validate(value string) error {
+	var number float64
+	var err error
+
+	switch c.kind {
+	case TypeInt:
+		var integer int
+
+		if integer, err = strconv.Atoi(value); err != nil {
+			return errors.New("an integer")
+		}
+
+		number = float64(integer)
+	case TypeFloat:
+		if number, err = strconv.ParseFloat(value, 64); err != nil {
+			return errors.New("a number")
+		}
+	case TypeBool:
+		switch strings.ToLower(value) {
+		case "1", "true", "yes", "on", "0", "false", "no", "off":
+		default:
+			return errors.New("a boolean")
+		}
+	case TypeDuration:
+		if _, err = time.ParseDuration(value); err != nil {
+			return errors.New("a duration")
+		}
+	case TypeUrl:
+		if parsed, err := url.Parse(value); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return errors.New("a URL")
+		}
+	case TypePath:
+		if _, err = os.Stat(value); c.mustExist && err != nil {
+			return errors.New("an existing path")
+		}
+	}
+
+	if len(c.choices) > 0 && !contains(c.choices, value) {
+		return errors.New(fmt.Sprintf("one of %s", strings.Join(c.choices, ", ")))
+	}
+
+	if c.kind == TypeInt || c.kind == TypeFloat {
+		if (c.min != nil && number < *c.min) || (c.max != nil && number > *c.max) {
+			return errors.New(fmt.Sprintf("a value %s", c.bounds()))
+		}
+	}
+
+	if c.pattern != nil && !c.pattern.MatchString(value) {
+		return errors.New(fmt.Sprintf("a value matching %s", c.pattern.String()))
+	}
+
+	return nil
+}
+
+// Placeholder return the value placeholder for the synopsis (e.g. debug|info|warn or int)
+func (c *Constraint) Placeholder() string {
+	if len(c.choices) > 0 {
+		return strings.Join(c.choices, "|")
+	}
+
+	return c.kind
+}
+
+// Describe return the constraints for the help (e.g. int, 1..65535)
+func (c *Constraint) Describe() string {
+	var parts []string
+
+	if len(c.choices) > 0 {
+		parts = append(parts, strings.Join(c.choices, "|"))
+	} else {
+		parts = append(parts, c.kind)
+	}
+
+	if c.min != nil || c.max != nil {
+		parts = append(parts, c.bounds())
+	}
+
+	if c.pattern != nil {
+		parts = append(parts, "matching "+c.pattern.String())
+	}
+
+	if c.mustExist {
+		parts = append(parts, "must exist")
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// bounds describe the min and max
+func (c *Constraint) bounds() string {
+	format := func(number float64) string {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+
+	switch {
+	case c.min != nil && c.max != nil:
+		return fmt.Sprintf("between %s and %s", format(*c.min), format(*c.max))
+	case c.min != nil:
+		return fmt.Sprintf(">= %s", format(*c.min))
+	case c.max != nil:
+		return fmt.Sprintf("<= %s", format(*c.max))
+	}
+
+	return ""
+}
+
+func contains(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -23,6 +23,10 @@ func New() *InputDefinition {
 		hasAnArrayArgument: false,
 
 		shortcuts: map[string]string{},
+
+		optionGroups: []*OptionGroup{},
+
+		syntax: DefaultSyntax(),
 	}
 
 	return def
@@ -42,6 +46,10 @@ type InputDefinition struct {
 	hasAnArrayArgument bool
 
 	shortcuts map[string]string
+
+	optionGroups []*OptionGroup
+
+	syntax Syntax
 }
 
 // Sets the InputArgument objects.
@@ -176,6 +184,14 @@ func (i *InputDefinition) AddOption(opt option.InputOption) *InputDefinition {
 		panic(errors.New(fmt.Sprintf("an option named '%s' already exists", opt.Name())))
 	}
 
+	if opt.IsNegatable() && i.HasOption("no-"+opt.Name()) {
+		panic(errors.New(fmt.Sprintf("an option named 'no-%s' already exists", opt.Name())))
+	}
+
+	if negated := strings.TrimPrefix(opt.Name(), "no-"); negated != opt.Name() && i.HasOption(negated) && i.Option(negated).IsNegatable() {
+		panic(errors.New(fmt.Sprintf("the option '%s' is already the negation of the '%s' option", opt.Name(), negated)))
+	}
+
 	if "" != opt.Shortcut() {
 		for _, shortcut := range strings.Split(opt.Shortcut(), "|") {
 			if i.HasShortcut(shortcut) && !opt.Equals(*i.FindOptionForShortcut(shortcut)) {
@@ -225,6 +241,28 @@ func (i *InputDefinition) OptionsOrder() []string {
 	return i.optionKeysOrdered
 }
 
+// adds a relation between options (e.g. definition.Exclusive("json", "table"))
+func (i *InputDefinition) AddOptionGroup(group *OptionGroup) *InputDefinition {
+	i.optionGroups = append(i.optionGroups, group)
+	return i
+}
+
+// Gets the option groups
+func (i *InputDefinition) OptionGroups() []*OptionGroup {
+	return i.optionGroups
+}
+
+// sets the command line syntax (e.g. definition.GnuSyntax())
+func (i *InputDefinition) SetSyntax(syntax Syntax) *InputDefinition {
+	i.syntax = syntax
+	return i
+}
+
+// Gets the command line syntax
+func (i *InputDefinition) Syntax() Syntax {
+	return i.syntax
+}
+
 // returns true if an InputOption object exists by shortcut.
 func (i *InputDefinition) HasShortcut(s string) bool {
 	_, found := i.shortcuts[s]
@@ -296,17 +334,39 @@ func (i *InputDefinition) Synopsis(short bool) string {
 				)
 			}
 
+			// typed values show their placeholder (e.g. --level=<debug|info|warn>)
+			if opt.IsAcceptValue() && opt.Constraint() != nil {
+				value = fmt.Sprintf(
+					"%s=<%s>%s",
+					start,
+					opt.Constraint().Placeholder(),
+					end,
+				)
+			}
+
 			shortcut := ""
 
 			if "" != opt.Shortcut() {
 				shortcut = fmt.Sprintf("-%s|", opt.Shortcut())
 			}
 
+			// count options can be repeated (e.g. -vvv)
+			if opt.IsCount() {
+				value = "..."
+			}
+
+			negation := ""
+
+			if opt.IsNegatable() {
+				negation = "[no-]"
+			}
+
 			elements = append(
 				elements,
 				fmt.Sprintf(
-					"[%s--%s%s]",
+					"[%s--%s%s%s]",
 					shortcut,
+					negation,
 					opt.Name(),
 					value,
 				),
@@ -324,6 +384,10 @@ func (i *InputDefinition) Synopsis(short bool) string {
 		arg := i.Argument(key)
 		element := fmt.Sprintf("<%s>", arg.Name())
 
+		if arg.Constraint() != nil {
+			element = fmt.Sprintf("<%s:%s>", arg.Name(), arg.Constraint().Placeholder())
+		}
+
 		if arg.IsList() {
 			element = fmt.Sprintf("%s...", element)
 		}
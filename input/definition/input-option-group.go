@@ -0,0 +1,160 @@
+package definition
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// relations between the options of a group
+const (
+	// only one of the options can be given
+	GroupExclusive = "exclusive"
+
+	// the options must be given together or not at all
+	GroupAllOrNone = "all-or-none"
+
+	// at least one of the options must be given
+	GroupAtLeastOne = "at-least-one"
+
+	// the first option requires all the others
+	GroupRequires = "requires"
+)
+
+// A OptionGroup represents a relation between several options
+type OptionGroup struct {
+	relation string
+	options  []string
+}
+
+// constructor, panic when the relation is unknown or less than two options are given
+func NewOptionGroup(relation string, options ...string) *OptionGroup {
+	switch relation {
+	case GroupExclusive, GroupAllOrNone, GroupAtLeastOne, GroupRequires:
+	default:
+		panic(errors.New(fmt.Sprintf("option group relation '%s' is not valid", relation)))
+	}
+
+	if len(options) < 2 {
+		panic(errors.New(fmt.Sprintf("a '%s' option group needs at least two options", relation)))
+	}
+
+	return &OptionGroup{
+		relation: relation,
+		options:  options,
+	}
+}
+
+// only one of the options can be given (e.g. --json and --table)
+func Exclusive(options ...string) *OptionGroup {
+	return NewOptionGroup(GroupExclusive, options...)
+}
+
+// the options must be given together or not at all
+func AllOrNone(options ...string) *OptionGroup {
+	return NewOptionGroup(GroupAllOrNone, options...)
+}
+
+// at least one of the options must be given
+func AtLeastOne(options ...string) *OptionGroup {
+	return NewOptionGroup(GroupAtLeastOne, options...)
+}
+
+// the option requires all the others (e.g. --user requires --password)
+func Requires(option string, required ...string) *OptionGroup {
+	return NewOptionGroup(GroupRequires, append([]string{option}, required...)...)
+}
+
+func (g *OptionGroup) Relation() string {
+	return g.relation
+}
+
+func (g *OptionGroup) Options() []string {
+	return g.options
+}
+
+// Check return an error when the given options break the relation
+func (g *OptionGroup) Check(given func(name string) bool) error {
+	var present []string
+	var missing []string
+
+	for _, name := range g.options {
+		if given(name) {
+			present = append(present, name)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+
+	switch g.relation {
+	case GroupExclusive:
+		if len(present) > 1 {
+			return errors.New(fmt.Sprintf("the options %s cannot be used together", quoteOptions(present)))
+		}
+	case GroupAllOrNone:
+		if len(present) > 0 && len(missing) > 0 {
+			return errors.New(fmt.Sprintf(
+				"the options %s must be used together, missing %s",
+				quoteOptions(g.options),
+				quoteOptions(missing),
+			))
+		}
+	case GroupAtLeastOne:
+		if len(present) == 0 {
+			return errors.New(fmt.Sprintf("at least one of the options %s is required", quoteOptions(g.options)))
+		}
+	case GroupRequires:
+		if !given(g.options[0]) {
+			return nil
+		}
+
+		var absent []string
+
+		for _, name := range g.options[1:] {
+			if !given(name) {
+				absent = append(absent, name)
+			}
+		}
+
+		if len(absent) > 0 {
+			return errors.New(fmt.Sprintf("the '--%s' option requires %s", g.options[0], quoteOptions(absent)))
+		}
+	}
+
+	return nil
+}
+
+// Describe return a short text of the relation for the help (e.g. "--json, --table: mutually exclusive")
+func (g *OptionGroup) Describe() string {
+	names := make([]string, len(g.options))
+
+	for index, name := range g.options {
+		names[index] = "--" + name
+	}
+
+	switch g.relation {
+	case GroupExclusive:
+		return fmt.Sprintf("%s: mutually exclusive", strings.Join(names, ", "))
+	case GroupAllOrNone:
+		return fmt.Sprintf("%s: all or none", strings.Join(names, ", "))
+	case GroupAtLeastOne:
+		return fmt.Sprintf("%s: at least one required", strings.Join(names, ", "))
+	}
+
+	return fmt.Sprintf("%s requires %s", names[0], strings.Join(names[1:], ", "))
+}
+
+// quoteOptions format option names as '--a', '--b' and '--c'
+func quoteOptions(names []string) string {
+	quoted := make([]string, len(names))
+
+	for index, name := range names {
+		quoted[index] = fmt.Sprintf("'--%s'", name)
+	}
+
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " and " + quoted[len(quoted)-1]
+}
@@ -0,0 +1,57 @@
+package definition
+
+// A Syntax represents the command line syntax accepted by ArgvInput
+type Syntax struct {
+	// SeparateValues accepts the value of a required option in the next token (e.g. -o value, --output value)
+	SeparateValues bool
+
+	// SeparateOptionalValues accepts the value of an optional option in the next token,
+	// otherwise the value must be attached (e.g. -ovalue, --output=value)
+	SeparateOptionalValues bool
+
+	// InterspersedOptions accepts options after positional arguments,
+	// otherwise the first argument ends the options (as POSIXLY_CORRECT does)
+	InterspersedOptions bool
+
+	// AbbreviatedOptions accepts unambiguous prefixes of long options (e.g. --verb for --verbose)
+	AbbreviatedOptions bool
+
+	// RepeatedLists accepts list options given several times (e.g. -e a -e b)
+	RepeatedLists bool
+
+	// ResponseFiles replaces @file tokens by the arguments read from the file
+	ResponseFiles bool
+
+	// ListSeparator splits the values of list options (e.g. "," for --tags=a,b), disabled when empty
+	ListSeparator string
+}
+
+// the syntax used by default
+func DefaultSyntax() Syntax {
+	return Syntax{
+		SeparateValues:         true,
+		SeparateOptionalValues: true,
+		InterspersedOptions:    true,
+		RepeatedLists:          true,
+	}
+}
+
+// the GNU getopt_long syntax, with response files and comma separated lists
+func GnuSyntax() Syntax {
+	return Syntax{
+		SeparateValues:      true,
+		InterspersedOptions: true,
+		AbbreviatedOptions:  true,
+		RepeatedLists:       true,
+		ResponseFiles:       true,
+		ListSeparator:       ",",
+	}
+}
+
+// the strict POSIX syntax, options must be given before the arguments
+func PosixSyntax() Syntax {
+	return Syntax{
+		SeparateValues: true,
+		RepeatedLists:  true,
+	}
+}
@@ -3,8 +3,23 @@ package input
 import (
 	"errors"
 	"fmt"
+	"github.com/DrSmithFr/go-console/helper"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/config"
+	"github.com/DrSmithFr/go-console/input/constraint"
 	"github.com/DrSmithFr/go-console/input/definition"
 	"github.com/DrSmithFr/go-console/input/option"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// sources of the argument and option values, by order of precedence
+const (
+	SourceCli     = "cli"
+	SourceEnv     = "env"
+	SourceFile    = "file"
+	SourceDefault = "default"
 )
 
 type abstractInput struct {
@@ -18,6 +33,11 @@ type abstractInput struct {
 	options      map[string]string
 	optionArrays map[string][]string
 
+	config *config.Values
+
+	// tokens remaining to parse, an option accepting a value may consume the next one
+	parsed []string
+
 	doParse    func()
 	doValidate func()
 }
@@ -54,11 +74,9 @@ func (i *abstractInput) Argument(name string) string {
 		panic(errors.New(fmt.Sprintf("the '%s' argument is an array, use ArgumentList() instead", name)))
 	}
 
-	if val, ok := i.arguments[name]; ok {
-		return val
-	}
+	val, _ := i.argumentValue(arg)
 
-	return arg.Default()
+	return val
 }
 
 // Returns the argument array value for a given argument name
@@ -73,11 +91,9 @@ func (i *abstractInput) ArgumentList(name string) []string {
 		panic(errors.New(fmt.Sprintf("the '%s' argument is not an array, use Argument() instead", name)))
 	}
 
-	if val, ok := i.argumentArrays[name]; ok {
-		return val
-	}
+	val, _ := i.argumentListValue(arg)
 
-	return arg.Defaults()
+	return val
 }
 
 // Sets an argument value by name
@@ -137,16 +153,9 @@ func (i *abstractInput) Option(name string) string {
 		panic(errors.New(fmt.Sprintf("the '%s' option is an array, use OptionList() instead", name)))
 	}
 
-	if val, ok := i.options[name]; ok {
-		return val
-	}
-
-	// TODO find a better way to handle option.None
-	if !opt.IsAcceptValue() {
-		return option.Undefined
-	}
+	val, _ := i.optionValue(opt)
 
-	return opt.Default()
+	return val
 }
 
 // Returns the option array value for a given option name
@@ -161,11 +170,9 @@ func (i *abstractInput) OptionList(name string) []string {
 		panic(errors.New(fmt.Sprintf("the '%s' option is not an array, use Option() instead", name)))
 	}
 
-	if val, ok := i.optionArrays[name]; ok {
-		return val
-	}
+	val, _ := i.optionListValue(opt)
 
-	return opt.Defaults()
+	return val
 }
 
 // Sets an option value by name
@@ -198,6 +205,143 @@ func (i *abstractInput) SetOptionList(name string, value []string) {
 	i.optionArrays[name] = value
 }
 
+// Sets the configuration file values, used when an argument or option is neither given nor found in the environment
+func (i *abstractInput) SetConfig(values *config.Values) {
+	i.config = values
+}
+
+// Returns the configuration file values (nil when not defined)
+func (i *abstractInput) Config() *config.Values {
+	return i.config
+}
+
+// Returns where the argument value comes from: SourceCli, SourceEnv, SourceFile or SourceDefault
+func (i *abstractInput) ArgumentSource(name string) string {
+	if !i.definition.HasArgument(name) {
+		panic(errors.New(fmt.Sprintf("the '%s' argument does not exist", name)))
+	}
+
+	arg := i.definition.Argument(name)
+
+	if arg.IsList() {
+		_, source := i.argumentListValue(arg)
+		return source
+	}
+
+	_, source := i.argumentValue(arg)
+	return source
+}
+
+// Returns where the option value comes from: SourceCli, SourceEnv, SourceFile or SourceDefault
+func (i *abstractInput) OptionSource(name string) string {
+	if !i.definition.HasOption(name) {
+		panic(errors.New(fmt.Sprintf("the '%s' option does not exist", name)))
+	}
+
+	opt := i.definition.Option(name)
+
+	if opt.IsList() {
+		_, source := i.optionListValue(opt)
+		return source
+	}
+
+	_, source := i.optionValue(opt)
+	return source
+}
+
+// resolve the argument value in order: command line, environment, configuration file and default
+func (i *abstractInput) argumentValue(arg *argument.InputArgument) (string, string) {
+	if val, ok := i.arguments[arg.Name()]; ok {
+		return val, SourceCli
+	}
+
+	if val, ok := lookupEnv(arg.Env()); ok {
+		return val, SourceEnv
+	}
+
+	if val, ok := i.config.Value(arg.Name()); ok {
+		return val, SourceFile
+	}
+
+	return arg.Default(), SourceDefault
+}
+
+// resolve the argument array value in order: command line, environment, configuration file and default
+func (i *abstractInput) argumentListValue(arg *argument.InputArgument) ([]string, string) {
+	if val, ok := i.argumentArrays[arg.Name()]; ok {
+		return val, SourceCli
+	}
+
+	if val, ok := lookupEnv(arg.Env()); ok {
+		return splitEnvList(val), SourceEnv
+	}
+
+	if val, ok := i.config.List(arg.Name()); ok {
+		return val, SourceFile
+	}
+
+	return arg.Defaults(), SourceDefault
+}
+
+// resolve the option value in order: command line, environment, configuration file and default
+func (i *abstractInput) optionValue(opt *option.InputOption) (string, string) {
+	if val, ok := i.options[opt.Name()]; ok {
+		return val, SourceCli
+	}
+
+	if val, ok := lookupEnv(opt.Env()); ok {
+		if !opt.IsAcceptValue() && !opt.IsCount() {
+			return flagValue(val), SourceEnv
+		}
+
+		return val, SourceEnv
+	}
+
+	if val, ok := i.config.Value(opt.Name()); ok {
+		if !opt.IsAcceptValue() && !opt.IsCount() {
+			return flagValue(val), SourceFile
+		}
+
+		return val, SourceFile
+	}
+
+	if opt.IsCount() {
+		if opt.Default() != "" {
+			return opt.Default(), SourceDefault
+		}
+
+		return "0", SourceDefault
+	}
+
+	// TODO find a better way to handle option.None
+	if !opt.IsAcceptValue() {
+		if opt.IsNegatable() && opt.Default() != "" {
+			return opt.Default(), SourceDefault
+		}
+
+		return option.Undefined, SourceDefault
+	}
+
+	return opt.Default(), SourceDefault
+}
+
+// resolve the option array value in order: command line, environment, configuration file and default
+func (i *abstractInput) optionListValue(opt *option.InputOption) ([]string, string) {
+	if val, ok := i.optionArrays[opt.Name()]; ok {
+		return val, SourceCli
+	}
+
+	if val, ok := lookupEnv(opt.Env()); ok {
+		return splitEnvList(val), SourceEnv
+	}
+
+	if val, ok := i.config.List(opt.Name()); ok {
+		return val, SourceFile
+	}
+
+	return opt.Defaults(), SourceDefault
+}
+
 // Is this input means interactive?
 func (i *abstractInput) IsInteractive() bool {
 	return i.interactive
@@ -218,15 +362,14 @@ func (i *abstractInput) initialize() {
 
 // Binds the current input instance with the given arguments and options
 func (i *abstractInput) Bind(def definition.InputDefinition) {
-	i.initialize()
-
 	i.definition = def
 
 	i.Parse()
 }
 
-// Processes command line arguments
+// Processes command line arguments, values of a previous parsing are dropped
 func (i *abstractInput) Parse() {
+	i.initialize()
 	i.doParse()
 }
 
@@ -234,3 +377,305 @@ func (i *abstractInput) Parse() {
 func (i *abstractInput) Validate() {
 	i.doValidate()
 }
+
+// lookupEnv returns the value of the environment variable bound to an argument or option
+func lookupEnv(name string) (string, bool) {
+	if name == "" {
+		return "", false
+	}
+
+	return os.LookupEnv(name)
+}
+
+// splitEnvList splits a comma separated environment variable into a list
+func splitEnvList(value string) []string {
+	values := []string{}
+
+	for _, val := range strings.Split(value, ",") {
+		if val = strings.TrimSpace(val); val != "" {
+			values = append(values, val)
+		}
+	}
+
+	return values
+}
+
+// flagValue converts an environment or configuration value into a flag value (1, true, yes and on enable the flag)
+func flagValue(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "1", "true", "yes", "on":
+		return option.Defined
+	}
+
+	return option.Undefined
+}
+
+//
+// parsing and validation shared by the inputs
+//
+
+func (i *abstractInput) parseArgument(token string) {
+	keys := i.definition.ArgumentsOrder()
+
+	nbArgs := i.countArguments()
+
+	// if input is expecting another argument, add it
+	if nbArgs < len(keys) && i.definition.HasArgument(keys[nbArgs]) {
+		arg := i.definition.Argument(keys[nbArgs])
+
+		if arg.IsList() {
+			i.argumentArrays[arg.Name()] = []string{token}
+		} else {
+			i.arguments[arg.Name()] = token
+		}
+
+		// if last argument isList(), append token to last argument
+	} else if nbArgs > 0 &&
+		nbArgs <= len(keys) &&
+		i.definition.HasArgument(keys[nbArgs-1]) &&
+		i.definition.Argument(keys[nbArgs-1]).IsList() {
+		arg := i.definition.Argument(keys[nbArgs-1])
+		i.argumentArrays[arg.Name()] = append(i.argumentArrays[arg.Name()], token)
+
+		// unexpected argument
+	} else {
+		all := i.Definition().Arguments()
+
+		if len(all) != 0 {
+			panic(
+				errors.New(
+					fmt.Sprintf(
+						"too many arguments, expected arguments '%s'",
+						helper.Implode(" ", ArgumentsMapKeys(all)),
+					),
+				),
+			)
+		}
+
+		panic(errors.New(fmt.Sprintf("no arguments expected, got '%s'", token)))
+	}
+}
+
+func (i *abstractInput) addShortOption(shortcut string, value string) {
+	if !i.definition.HasShortcut(shortcut) {
+		panic(errors.New(fmt.Sprintf("the '-%s' option does not exist", shortcut)))
+	}
+
+	opt := i.definition.FindOptionForShortcut(shortcut)
+
+	i.addLongOption(opt.Name(), value)
+}
+
+func (i *abstractInput) addLongOption(name string, value string) {
+	if negated := strings.TrimPrefix(name, "no-"); !i.definition.HasOption(name) && negated != name &&
+		i.definition.HasOption(negated) && i.definition.Option(negated).IsNegatable() {
+		if "" != value {
+			panic(errors.New(fmt.Sprintf("the '--%s' option does not accept a value", name)))
+		}
+
+		i.options[negated] = option.Undefined
+		return
+	}
+
+	if !i.definition.HasOption(name) {
+		panic(errors.New(i.unknownOptionMessage(name)))
+	}
+
+	opt := i.definition.Option(name)
+
+	if opt.IsCount() {
+		i.addCountOption(name, value)
+		return
+	}
+
+	if "" != value && !opt.IsAcceptValue() {
+		panic(errors.New(fmt.Sprintf("the '--%s' option does not accept a value", name)))
+	} else if !opt.IsAcceptValue() {
+		// TODO find a better way to handle option.None
+		value = option.Defined
+	}
+
+	if "" == value && i.acceptSeparateValue(name) && len(i.parsed) > 0 {
+		// if option accepts an optional or mandatory argument
+		// let's see if there is one provided
+		next := i.parsed[0]
+		i.parsed = i.parsed[1:]
+
+		if len(next) > 0 && next[0] != '-' || "" == next {
+			value = next
+		} else {
+			i.parsed = append([]string{next}, i.parsed...)
+		}
+	}
+
+	if "" == value {
+		if opt.IsValueRequired() {
+			panic(errors.New(fmt.Sprintf("the '--%s' option requires a value", name)))
+		}
+
+		if !opt.IsList() && !opt.IsValueOptional() {
+			value = option.Defined
+		}
+	}
+
+	if !opt.IsList() {
+		i.options[name] = value
+		return
+	}
+
+	syntax := i.definition.Syntax()
+
+	if !syntax.RepeatedLists && len(i.optionArrays[name]) > 0 {
+		panic(errors.New(fmt.Sprintf("the '--%s' option cannot be given more than once", name)))
+	}
+
+	if "" != syntax.ListSeparator && "" != value {
+		i.optionArrays[name] = append(i.optionArrays[name], strings.Split(value, syntax.ListSeparator)...)
+	} else {
+		i.optionArrays[name] = append(i.optionArrays[name], value)
+	}
+}
+
+// acceptSeparateValue return true when the option value can be given in the next token (e.g. --output value)
+func (i *abstractInput) acceptSeparateValue(name string) bool {
+	if !i.definition.HasOption(name) {
+		return false
+	}
+
+	opt := i.definition.Option(name)
+	syntax := i.definition.Syntax()
+
+	return opt.IsValueRequired() && syntax.SeparateValues || opt.IsValueOptional() && syntax.SeparateOptionalValues
+}
+
+// addCountOption count the occurrences of the option, --name=N sets the count explicitly
+func (i *abstractInput) addCountOption(name string, value string) {
+	count, _ := strconv.Atoi(i.options[name])
+
+	if "" == value {
+		i.options[name] = strconv.Itoa(count + 1)
+		return
+	}
+
+	if n, err := strconv.Atoi(value); err != nil || n < 0 {
+		panic(errors.New(fmt.Sprintf("the '--%s' option expects a number of occurrences, '%s' given", name, value)))
+	}
+
+	i.options[name] = value
+}
+
+// unknownOptionMessage build the unknown option error, suggesting the closest option names
+func (i *abstractInput) unknownOptionMessage(name string) string {
+	message := fmt.Sprintf("the '--%s' option does not exist", name)
+
+	alternatives := helper.Alternatives(name, i.definition.OptionsOrder())
+	alternatives = helper.Map(alternatives, func(alternative string) string {
+		return "--" + alternative
+	})
+
+	if suggestion := helper.DidYouMean(alternatives); suggestion != "" {
+		message = fmt.Sprintf("%s. %s", message, suggestion)
+	}
+
+	return message
+}
+
+func (i *abstractInput) countArguments() int {
+	return len(i.arguments) + len(i.argumentArrays)
+}
+
+func ArgumentsMapKeys(inputs map[string]argument.InputArgument) []string {
+	var keys []string
+
+	for k := range inputs {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// validate check the required values, the constraints and the option groups
+func (i *abstractInput) validate() {
+	for _, arg := range i.definition.Arguments() {
+		if arg.IsRequired() && !arg.IsList() && i.Argument(arg.Name()) == "" {
+			panic(errors.New(fmt.Sprintf("Argument '%s' is required", arg.Name())))
+		}
+
+		if arg.IsRequired() && arg.IsList() && len(i.ArgumentList(arg.Name())) == 0 {
+			panic(errors.New(fmt.Sprintf("Argument '%s' is required", arg.Name())))
+		}
+	}
+
+	for _, opt := range i.definition.Options() {
+		if opt.IsValueRequired() && !opt.IsList() && i.Option(opt.Name()) == "" {
+			panic(errors.New(fmt.Sprintf("Option '%s' is required", opt.Name())))
+		}
+
+		if opt.IsValueRequired() && opt.IsList() && len(i.OptionList(opt.Name())) == 0 {
+			panic(errors.New(fmt.Sprintf("Option '%s' is required", opt.Name())))
+		}
+	}
+
+	for _, key := range i.definition.ArgumentsOrder() {
+		arg := i.definition.Argument(key)
+
+		if arg.IsList() {
+			validateConstraint("argument", key, arg.Constraint(), i.ArgumentList(key))
+		} else {
+			validateConstraint("argument", key, arg.Constraint(), []string{i.Argument(key)})
+		}
+	}
+
+	for _, key := range i.definition.OptionsOrder() {
+		opt := i.definition.Option(key)
+
+		if opt.IsList() {
+			validateConstraint("option", key, opt.Constraint(), i.OptionList(key))
+		} else if opt.IsAcceptValue() {
+			validateConstraint("option", key, opt.Constraint(), []string{i.Option(key)})
+		}
+	}
+
+	// an option is given when its value does not come from the default,
+	// a negated or disabled flag (e.g. --no-json, APP_JSON=false) is not
+	given := func(name string) bool {
+		if i.OptionSource(name) == SourceDefault {
+			return false
+		}
+
+		if opt := i.definition.Option(name); !opt.IsAcceptValue() && !opt.IsCount() {
+			return i.Option(name) == option.Defined
+		}
+
+		return true
+	}
+
+	for _, group := range i.definition.OptionGroups() {
+		for _, name := range group.Options() {
+			if !i.definition.HasOption(name) {
+				panic(errors.New(fmt.Sprintf("the '%s' option of a group does not exist", name)))
+			}
+		}
+
+		if err := group.Check(given); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// validateConstraint panic with an InvalidValueError when a given value does not follow the constraint
+func validateConstraint(kind string, name string, c *constraint.Constraint, values []string) {
+	if c == nil {
+		return
+	}
+
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+
+		if err := c.Validate(value); err != nil {
+			panic(&InvalidValueError{Kind: kind, Name: name, Value: value, Expected: err.Error()})
+		}
+	}
+}
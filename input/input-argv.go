@@ -3,10 +3,7 @@ package input
 import (
 	"errors"
 	"fmt"
-	"github.com/DrSmithFr/go-console/helper"
-	"github.com/DrSmithFr/go-console/input/argument"
 	"github.com/DrSmithFr/go-console/input/definition"
-	"github.com/DrSmithFr/go-console/input/option"
 	"os"
 	"regexp"
 	"strings"
@@ -34,7 +31,11 @@ func NewArgvInput(argv []string) *ArgvInput {
 type ArgvInput struct {
 	abstractInput
 	tokens []string
-	parsed []string
+}
+
+// Returns the raw parameters (not parsed), without the program name
+func (i *ArgvInput) Tokens() []string {
+	return i.tokens
 }
 
 // Returns the first argument from the raw parameters (not parsed)
@@ -52,12 +53,96 @@ func (i *ArgvInput) FirstArgument() string {
 
 // Returns true if the raw parameters (not parsed) contain a value
 func (i *ArgvInput) HasParameterOption(values []string, onlyParams bool) bool {
-	panic("implement me")
+	for _, token := range i.tokens {
+		if onlyParams && "--" == token {
+			return false
+		}
+
+		for _, value := range values {
+			if found, _ := i.matchParameterOption(token, value); found {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
 // Returns the value of a raw option (not parsed).
-func (i *ArgvInput) ParameterOption(values []string, defaultValue string, onlyParams bool) {
-	panic("implement me")
+func (i *ArgvInput) ParameterOption(values []string, defaultValue string, onlyParams bool) string {
+	for index, token := range i.tokens {
+		if onlyParams && "--" == token {
+			return defaultValue
+		}
+
+		for _, value := range values {
+			found, attached := i.matchParameterOption(token, value)
+
+			if !found {
+				continue
+			}
+
+			if "" != attached {
+				return attached
+			}
+
+			// the value may be the next token (e.g. --env prod)
+			if index+1 < len(i.tokens) {
+				if next := i.tokens[index+1]; "" == next || next[0] != '-' {
+					return next
+				}
+			}
+
+			return ""
+		}
+	}
+
+	return defaultValue
+}
+
+// matchParameterOption check if the token is the option value (e.g. --env, -e),
+// returning the value attached to the token (e.g. --env=prod, -eprod, -vvvvprod)
+func (i *ArgvInput) matchParameterOption(token string, value string) (bool, string) {
+	if token == value {
+		return true, ""
+	}
+
+	if strings.HasPrefix(value, "--") {
+		if strings.HasPrefix(token, value+"=") {
+			return true, token[len(value)+1:]
+		}
+
+		return false, ""
+	}
+
+	if !strings.HasPrefix(value, "-") || !strings.HasPrefix(token, "-") || strings.HasPrefix(token, "--") {
+		return false, ""
+	}
+
+	if len(value) != 2 {
+		if strings.HasPrefix(token, value) {
+			return true, token[len(value):]
+		}
+
+		return false, ""
+	}
+
+	// combined short flags (e.g. -vx), the characters following an option accepting a value are its value
+	flags := token[1:]
+
+	for index := 0; index < len(flags); index++ {
+		shortcut := flags[index : index+1]
+
+		if shortcut == value[1:] {
+			return true, flags[index+1:]
+		}
+
+		if i.definition.HasShortcut(shortcut) && i.definition.FindOptionForShortcut(shortcut).IsAcceptValue() {
+			return false, ""
+		}
+	}
+
+	return false, ""
 }
 
 // parse cli argv
@@ -65,6 +150,10 @@ func (i *ArgvInput) ParseArgv() {
 	parseOptions := true
 	i.parsed = i.tokens
 
+	if i.definition.Syntax().ResponseFiles {
+		i.parsed = expandResponseFiles(i.tokens)
+	}
+
 	for {
 		if 0 == len(i.parsed) {
 			break
@@ -83,6 +172,11 @@ func (i *ArgvInput) ParseArgv() {
 			i.parseShortOption(token)
 		} else {
 			i.parseArgument(token)
+
+			// the first argument ends the options
+			if !i.definition.Syntax().InterspersedOptions {
+				parseOptions = false
+			}
 		}
 
 	}
@@ -149,144 +243,93 @@ func (i *ArgvInput) parseLongOption(token string) {
 
 	if pos != -1 {
 		value := name[pos+1:]
+		name = i.expandLongOption(name[0:pos])
 
-		if 0 == len(value) {
+		// an explicit empty value must not be taken from the next token
+		if 0 == len(value) && i.acceptSeparateValue(name) {
 			i.parsed = append([]string{value}, i.parsed...)
 		}
 
-		i.addLongOption(name[0:pos], value)
+		i.addLongOption(name, value)
 	} else {
-		i.addLongOption(name, "")
+		i.addLongOption(i.expandLongOption(name), "")
 	}
 }
 
-func (i *ArgvInput) parseArgument(token string) {
-	keys := i.definition.ArgumentsOrder()
+// expandLongOption return the option matching an unambiguous prefix (e.g. --verb for --verbose)
+func (i *ArgvInput) expandLongOption(name string) string {
+	if !i.definition.Syntax().AbbreviatedOptions || i.definition.HasOption(name) {
+		return name
+	}
 
-	nbArgs := i.countArguments()
+	var matches []string
 
-	// if input is expecting another argument, add it
-	if nbArgs < len(keys) && i.definition.HasArgument(keys[nbArgs]) {
-		arg := i.definition.Argument(keys[nbArgs])
+	for _, key := range i.definition.OptionsOrder() {
+		candidates := []string{key}
 
-		if arg.IsList() {
-			i.argumentArrays[arg.Name()] = []string{token}
-		} else {
-			i.arguments[arg.Name()] = token
+		if i.definition.Option(key).IsNegatable() {
+			candidates = append(candidates, "no-"+key)
 		}
 
-		// if last argument isList(), append token to last argument
-	} else if nbArgs-1 <= len(keys) &&
-		i.definition.HasArgument(keys[nbArgs-1]) &&
-		i.definition.Argument(keys[nbArgs-1]).IsList() {
-		arg := i.definition.Argument(keys[nbArgs-1])
-		i.argumentArrays[arg.Name()] = append(i.argumentArrays[arg.Name()], token)
+		for _, candidate := range candidates {
+			if candidate == name {
+				return name
+			}
 
-		// unexpected argument
-	} else {
-		all := i.Definition().Arguments()
-
-		if len(all) != 0 {
-			panic(
-				errors.New(
-					fmt.Sprintf(
-						"too many arguments, expected arguments '%s'",
-						helper.Implode(" ", ArgumentsMapKeys(all)),
-					),
-				),
-			)
+			if strings.HasPrefix(candidate, name) {
+				matches = append(matches, candidate)
+			}
 		}
-
-		panic(errors.New(fmt.Sprintf("no arguments expected, got '%s'", token)))
 	}
-}
 
-func (i *ArgvInput) addShortOption(shortcut string, value string) {
-	if !i.definition.HasShortcut(shortcut) {
-		panic(errors.New(fmt.Sprintf("the '-%s' option does not exist", shortcut)))
+	if len(matches) > 1 {
+		panic(errors.New(fmt.Sprintf(
+			"the '--%s' option is ambiguous, it could be '--%s'",
+			name,
+			strings.Join(matches, "', '--"),
+		)))
 	}
 
-	opt := i.definition.FindOptionForShortcut(shortcut)
-
-	i.addLongOption(opt.Name(), value)
-}
-
-func (i *ArgvInput) addLongOption(name string, value string) {
-	if !i.definition.HasOption(name) {
-		panic(errors.New(fmt.Sprintf("the '--%s' option does not exist", name)))
+	if 1 == len(matches) {
+		return matches[0]
 	}
 
-	opt := i.definition.Option(name)
-
-	if "" != value && !opt.IsAcceptValue() {
-		panic(errors.New(fmt.Sprintf("the '--%s' option does not accept a value", name)))
-	} else if !opt.IsAcceptValue() {
-		// TODO find a better way to handle option.None
-		value = option.Defined
-	}
+	return name
+}
 
-	if "" == value && opt.IsAcceptValue() && len(i.parsed) > 0 {
-		// if option accepts an optional or mandatory argument
-		// let's see if there is one provided
-		next := i.parsed[0]
-		i.parsed = i.parsed[1:]
+// expandResponseFiles replace the @file tokens by the arguments read from the file
+func expandResponseFiles(tokens []string) []string {
+	expanded := []string{}
 
-		if len(next) > 0 && next[0] != '-' || "" == next {
-			value = next
-		} else {
-			i.parsed = append([]string{next}, i.parsed...)
+	for index, token := range tokens {
+		if "--" == token {
+			return append(expanded, tokens[index:]...)
 		}
-	}
 
-	if "" == value {
-		if opt.IsValueRequired() {
-			panic(errors.New(fmt.Sprintf("the '--%s' option requires a value", name)))
+		if len(token) < 2 || '@' != token[0] {
+			expanded = append(expanded, token)
+			continue
 		}
 
-		if !opt.IsList() && !opt.IsValueOptional() {
-			value = option.Defined
-		}
-	}
+		content, err := os.ReadFile(token[1:])
 
-	if opt.IsList() {
-		i.optionArrays[name] = append(i.optionArrays[name], value)
-	} else {
-		i.options[name] = value
-	}
-}
+		if err != nil {
+			panic(errors.New(fmt.Sprintf("cannot read the response file '%s'", token[1:])))
+		}
 
-func (i *ArgvInput) countArguments() int {
-	return len(i.arguments) + len(i.argumentArrays)
-}
+		tokens, err := tokenize(string(content))
 
-func ArgumentsMapKeys(inputs map[string]argument.InputArgument) []string {
-	var keys []string
+		if err != nil {
+			panic(errors.New(fmt.Sprintf("cannot read the response file '%s': %s", token[1:], err)))
+		}
 
-	for k := range inputs {
-		keys = append(keys, k)
+		expanded = append(expanded, tokens...)
 	}
 
-	return keys
+	return expanded
 }
 
+// Validates the arguments and options
 func (i *ArgvInput) ValidateArgv() {
-	for _, arg := range i.definition.Arguments() {
-		if arg.IsRequired() && !arg.IsList() && i.Argument(arg.Name()) == "" {
-			panic(errors.New(fmt.Sprintf("Argument '%s' is required", arg.Name())))
-		}
-
-		if arg.IsRequired() && arg.IsList() && len(i.ArgumentList(arg.Name())) == 0 {
-			panic(errors.New(fmt.Sprintf("Argument '%s' is required", arg.Name())))
-		}
-	}
-
-	for _, opt := range i.definition.Options() {
-		if opt.IsValueRequired() && !opt.IsList() && i.Option(opt.Name()) == "" {
-			panic(errors.New(fmt.Sprintf("Option '%s' is required", opt.Name())))
-		}
-
-		if opt.IsValueRequired() && opt.IsList() && len(i.OptionList(opt.Name())) == 0 {
-			panic(errors.New(fmt.Sprintf("Option '%s' is required", opt.Name())))
-		}
-	}
+	i.validate()
 }
@@ -0,0 +1,198 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// constructor, keys are argument names or options (e.g. "--force", "-f"),
+// values are strings, numbers, booleans (flags) or slices (list arguments and options)
+func NewArrayInput(parameters map[string]interface{}) *ArrayInput {
+	input := new(ArrayInput)
+
+	if nil == parameters {
+		parameters = map[string]interface{}{}
+	}
+
+	input.parameters = parameters
+	input.doParse = input.ParseArray
+	input.doValidate = input.ValidateArray
+	input.initialize()
+	input.definition = *definition.New()
+
+	return input
+}
+
+// ArrayInput represents an input provided as a map (e.g. {"command": "db:migrate", "--force": true})
+type ArrayInput struct {
+	abstractInput
+	parameters map[string]interface{}
+}
+
+// Returns the parameters (not parsed)
+func (i *ArrayInput) Parameters() map[string]interface{} {
+	return i.parameters
+}
+
+// Returns the value of the first argument in key order
+func (i *ArrayInput) FirstArgument() string {
+	for _, key := range i.keys() {
+		if strings.HasPrefix(key, "-") {
+			continue
+		}
+
+		if values := arrayValues(i.parameters[key]); len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	panic(errors.New("first argument not found"))
+}
+
+// Returns true if the parameters contain one of the options (onlyParams is meaningless for a map)
+func (i *ArrayInput) HasParameterOption(values []string, onlyParams bool) bool {
+	for _, value := range values {
+		if _, ok := i.parameters[value]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the value of an option from the parameters (not parsed)
+func (i *ArrayInput) ParameterOption(values []string, defaultValue string, onlyParams bool) string {
+	for _, value := range values {
+		param, ok := i.parameters[value]
+
+		if !ok {
+			continue
+		}
+
+		if found := arrayValues(param); len(found) > 0 {
+			return found[0]
+		}
+
+		return ""
+	}
+
+	return defaultValue
+}
+
+// parse the parameters
+func (i *ArrayInput) ParseArray() {
+	for _, key := range i.keys() {
+		value := i.parameters[key]
+
+		if strings.HasPrefix(key, "--") {
+			i.addArrayOption(key[2:], value)
+		} else if strings.HasPrefix(key, "-") && "-" != key {
+			i.addArrayOption(i.definition.ShortcutToName(key[1:]), value)
+		} else {
+			i.addArrayArgument(key, value)
+		}
+	}
+}
+
+// Validates the arguments and options
+func (i *ArrayInput) ValidateArray() {
+	i.validate()
+}
+
+//
+// internal
+//
+
+// keys return the parameter keys sorted, to parse them in a stable order
+func (i *ArrayInput) keys() []string {
+	keys := make([]string, 0, len(i.parameters))
+
+	for key := range i.parameters {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (i *ArrayInput) addArrayOption(name string, value interface{}) {
+	if flag, ok := value.(bool); ok {
+		if flag {
+			i.addLongOption(name, "")
+		} else if i.definition.HasOption(name) && i.definition.Option(name).IsNegatable() {
+			// false disables a negatable option, as --no-name does
+			i.addLongOption("no-"+name, "")
+		}
+
+		return
+	}
+
+	values := arrayValues(value)
+
+	if 0 == len(values) {
+		i.addLongOption(name, "")
+		return
+	}
+
+	// lists are given at once, the command line syntax (repeated or separated values) does not apply
+	if i.definition.HasOption(name) && i.definition.Option(name).IsList() {
+		i.optionArrays[name] = append(i.optionArrays[name], values...)
+		return
+	}
+
+	for _, val := range values {
+		i.addLongOption(name, val)
+	}
+}
+
+func (i *ArrayInput) addArrayArgument(name string, value interface{}) {
+	if !i.definition.HasArgument(name) {
+		panic(errors.New(fmt.Sprintf("the '%s' argument does not exist", name)))
+	}
+
+	values := arrayValues(value)
+
+	if i.definition.Argument(name).IsList() {
+		i.argumentArrays[name] = values
+		return
+	}
+
+	if len(values) > 1 {
+		panic(errors.New(fmt.Sprintf("the '%s' argument does not accept a list", name)))
+	}
+
+	if 1 == len(values) {
+		i.arguments[name] = values[0]
+	}
+}
+
+// arrayValues converts a parameter value into its string values
+func arrayValues(value interface{}) []string {
+	switch val := value.(type) {
+	case nil:
+		return []string{}
+	case string:
+		return []string{val}
+	case []string:
+		return val
+	}
+
+	reflected := reflect.ValueOf(value)
+
+	if reflected.Kind() == reflect.Slice || reflected.Kind() == reflect.Array {
+		values := make([]string, reflected.Len())
+
+		for index := range values {
+			values[index] = fmt.Sprint(reflected.Index(index).Interface())
+		}
+
+		return values
+	}
+
+	return []string{fmt.Sprint(value)}
+}
@@ -1,7 +1,9 @@
 package input
 
 import (
+	"github.com/DrSmithFr/go-console/input/config"
 	"github.com/DrSmithFr/go-console/input/definition"
+	"time"
 )
 
 // InputInterface is the interface implemented by all input classes.
@@ -13,17 +15,18 @@ type InputInterface interface {
 	//
 	// This method is to be used to introspect the input parameters
 	// before they have been validated. It must be used carefully.
-	// Does not necessarily return the correct result for short options
-	// when multiple flags are combined in the same option.
+	// Short options combined in the same token (e.g. -vx) are only split
+	// correctly once the definition is bound.
 	HasParameterOption(values []string, onlyParams bool) bool
 
 	// Returns the value of a raw option (not parsed).
 	//
 	// This method is to be used to introspect the input parameters
 	// before they have been validated. It must be used carefully.
-	// Does not necessarily return the correct result for short options
-	// when multiple flags are combined in the same option.
-	ParameterOption(values []string, defaultValue string, onlyParams bool)
+	// Short options combined in the same token (e.g. -vx) are only split
+	// correctly once the definition is bound.
+	// The default value is returned when the option is not given.
+	ParameterOption(values []string, defaultValue string, onlyParams bool) string
 
 	// Binds the current input instance with the given arguments and options.
 	Bind(definition definition.InputDefinition)
@@ -46,6 +49,16 @@ type InputInterface interface {
 	// Returns the argument array value for a given array argument name.
 	ArgumentList(name string) []string
 
+	// Typed argument accessors, panic with an InvalidValueError when the value cannot be converted.
+	ArgumentInt(name string) int
+	ArgumentFloat(name string) float64
+	ArgumentBool(name string) bool
+	ArgumentDuration(name string) time.Duration
+	ArgumentTime(name string, layout string) time.Time
+	ArgumentIntList(name string) []int
+	ArgumentFloatList(name string) []float64
+	ArgumentDurationList(name string) []time.Duration
+
 	// Set the argument value for a given argument name.
 	SetArgument(name string, value string)
 
@@ -67,6 +80,18 @@ type InputInterface interface {
 	// Returns the option array value for a given array option name.
 	OptionList(name string) []string
 
+	// Typed option accessors, panic with an InvalidValueError when the value cannot be converted.
+	OptionInt(name string) int
+	OptionFloat(name string) float64
+	OptionBool(name string) bool
+	OptionNegatable(name string) *bool
+	OptionCount(name string) int
+	OptionDuration(name string) time.Duration
+	OptionTime(name string, layout string) time.Time
+	OptionIntList(name string) []int
+	OptionFloatList(name string) []float64
+	OptionDurationList(name string) []time.Duration
+
 	// Sets an option value by name.
 	SetOption(name string, value string)
 
@@ -84,4 +109,16 @@ type InputInterface interface {
 
 	// Get the input definition
 	Definition() *definition.InputDefinition
+
+	// Sets the configuration file values, used after the command line and the environment.
+	SetConfig(values *config.Values)
+
+	// Returns the configuration file values (nil when not defined).
+	Config() *config.Values
+
+	// Returns where the argument value comes from (cli, env, file or default).
+	ArgumentSource(name string) string
+
+	// Returns where the option value comes from (cli, env, file or default).
+	OptionSource(name string) string
 }
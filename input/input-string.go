@@ -0,0 +1,108 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"strings"
+	"unicode"
+)
+
+// constructor, the string is split into tokens as a shell would (e.g. `deploy "my app" --env=prod`),
+// an invalid string (e.g. unterminated quote) is reported when parsing
+func NewStringInput(input string) *StringInput {
+	in := new(StringInput)
+
+	in.tokens, in.err = tokenize(input)
+	in.doParse = in.ParseString
+	in.doValidate = in.ValidateArgv
+	in.initialize()
+	in.definition = *definition.New()
+
+	return in
+}
+
+// StringInput represents an input provided as a string
+type StringInput struct {
+	ArgvInput
+	err error
+}
+
+// parse the string tokens
+func (i *StringInput) ParseString() {
+	if i.err != nil {
+		panic(i.err)
+	}
+
+	i.ParseArgv()
+}
+
+// Returns the raw parameters (not parsed), panics when the string cannot be split into tokens
+func (i *StringInput) Tokens() []string {
+	if i.err != nil {
+		panic(i.err)
+	}
+
+	return i.tokens
+}
+
+// tokenize split the string on whitespaces, handling single quotes, double quotes and backslash escapes
+func tokenize(input string) ([]string, error) {
+	tokens := []string{}
+
+	var token strings.Builder
+	var quote rune
+
+	inToken := false
+	escaped := false
+	runes := []rune(input)
+
+	for index, char := range runes {
+		switch {
+		case escaped:
+			token.WriteRune(char)
+			escaped = false
+		case '\\' == char && '\'' != quote:
+			inToken = true
+
+			// in double quotes, only quotes and backslashes are escaped
+			if '"' == quote && (index+1 == len(runes) || ('"' != runes[index+1] && '\\' != runes[index+1])) {
+				token.WriteRune(char)
+			} else {
+				escaped = true
+			}
+		case 0 != quote:
+			if char == quote {
+				quote = 0
+			} else {
+				token.WriteRune(char)
+			}
+		case '\'' == char || '"' == char:
+			quote = char
+			inToken = true
+		case unicode.IsSpace(char):
+			if inToken {
+				tokens = append(tokens, token.String())
+				token.Reset()
+				inToken = false
+			}
+		default:
+			token.WriteRune(char)
+			inToken = true
+		}
+	}
+
+	if 0 != quote {
+		return nil, errors.New(fmt.Sprintf("unterminated quote in '%s'", input))
+	}
+
+	if escaped {
+		token.WriteRune('\\')
+	}
+
+	if inToken {
+		tokens = append(tokens, token.String())
+	}
+
+	return tokens, nil
+}
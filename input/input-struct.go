@@ -0,0 +1,463 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/constraint"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ConsoleTag usage: DryRun bool `console:"option=dry-run,shortcut=d,desc=Simulate the changes"`
+	ConsoleTag = "console"
+
+	// tag entries
+	argumentTagKey  = "argument"
+	optionTagKey    = "option"
+	shortcutTagKey  = "shortcut"
+	descTagKey      = "desc"
+	defaultTagKey   = "default"
+	envTagKey       = "env"
+	enumTagKey      = "enum"
+	minTagKey       = "min"
+	maxTagKey       = "max"
+	requiredTagKey  = "required"
+	negatableTagKey = "negatable"
+)
+
+var tagEntryRegex = regexp.MustCompile(`^\s*(argument|option|shortcut|desc|default|env|enum|min|max|required|negatable)\s*(=|$)`)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// boundField is a struct field bound to an argument or an option
+type boundField struct {
+	index    []int
+	field    reflect.StructField
+	kind     string
+	name     string
+	entries  map[string]string
+	required bool
+}
+
+// StructDefinition create the arguments and options declared by the tagged fields of the struct pointer,
+// the current field values are used as default values
+func StructDefinition(target interface{}) *definition.InputDefinition {
+	def := definition.New()
+	value := structValue(target)
+
+	for _, bound := range boundFields(value.Type(), nil) {
+		fieldValue := value.FieldByIndex(bound.index)
+
+		if bound.kind == argumentTagKey {
+			def.AddArgument(*bound.inputArgument(fieldValue))
+		} else {
+			def.AddOption(*bound.inputOption(fieldValue))
+		}
+	}
+
+	return def
+}
+
+// BindStruct write the argument and option values into the tagged fields of the struct pointer,
+// panic with an InvalidValueError when a value cannot be converted to the field type
+func BindStruct(in InputInterface, target interface{}) {
+	value := structValue(target)
+
+	for _, bound := range boundFields(value.Type(), nil) {
+		fieldValue := value.FieldByIndex(bound.index)
+
+		if bound.kind == argumentTagKey {
+			if fieldValue.Kind() == reflect.Slice {
+				bound.setList(fieldValue, in.ArgumentList(bound.name))
+			} else {
+				bound.set(fieldValue, in.Argument(bound.name))
+			}
+
+			continue
+		}
+
+		switch {
+		case fieldValue.Kind() == reflect.Slice:
+			bound.setList(fieldValue, in.OptionList(bound.name))
+		case fieldValue.Kind() == reflect.Bool:
+			fieldValue.SetBool(in.OptionBool(bound.name))
+		default:
+			bound.set(fieldValue, in.Option(bound.name))
+		}
+	}
+}
+
+// structValue return the struct pointed by target
+func structValue(target interface{}) reflect.Value {
+	value := reflect.ValueOf(target)
+
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		panic(errors.New(fmt.Sprintf("cannot bind input to %T, a struct pointer is expected", target)))
+	}
+
+	return value.Elem()
+}
+
+// boundFields list the tagged fields, including those of embedded structs
+func boundFields(typ reflect.Type, parent []int) []boundField {
+	var fields []boundField
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		index := append(append([]int{}, parent...), i)
+		tag, tagged := field.Tag.Lookup(ConsoleTag)
+
+		if tag == "-" {
+			continue
+		}
+
+		// embedded structs (even unexported) share their exported fields
+		if !tagged && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, boundFields(field.Type, index)...)
+			continue
+		}
+
+		if !tagged || field.PkgPath != "" {
+			continue
+		}
+
+		bound := boundField{
+			index:   index,
+			field:   field,
+			kind:    optionTagKey,
+			name:    kebabCase(field.Name),
+			entries: parseTag(tag),
+		}
+
+		if name, ok := bound.entries[argumentTagKey]; ok {
+			bound.kind = argumentTagKey
+			bound.name = name
+		} else if name, ok := bound.entries[optionTagKey]; ok {
+			bound.name = name
+		}
+
+		_, bound.required = bound.entries[requiredTagKey]
+
+		if !isSupportedType(field.Type) {
+			panic(errors.New(fmt.Sprintf("the field '%s' of type %s cannot be bound to the '%s' %s", field.Name, field.Type, bound.name, bound.kind)))
+		}
+
+		fields = append(fields, bound)
+	}
+
+	return fields
+}
+
+// parseTag split the tag entries, commas not followed by a known entry belong to the previous value
+func parseTag(tag string) map[string]string {
+	entries := map[string]string{}
+	last := ""
+
+	for _, part := range strings.Split(tag, ",") {
+		if !tagEntryRegex.MatchString(part) {
+			if last != "" {
+				entries[last] += "," + part
+			}
+
+			continue
+		}
+
+		key, value, _ := strings.Cut(part, "=")
+		last = strings.TrimSpace(key)
+		entries[last] = strings.TrimSpace(value)
+	}
+
+	return entries
+}
+
+// inputArgument create the argument declared by the field
+func (b boundField) inputArgument(fieldValue reflect.Value) *argument.InputArgument {
+	mode := argument.Optional
+
+	if b.required {
+		mode = argument.Required
+	}
+
+	if fieldValue.Kind() == reflect.Slice {
+		mode |= argument.List
+	}
+
+	arg := argument.New(b.name, mode).
+		SetDescription(b.entries[descTagKey]).
+		SetEnv(b.entries[envTagKey]).
+		SetConstraint(b.constraint())
+
+	if fieldValue.Kind() == reflect.Slice {
+		if defaults := b.defaults(fieldValue); len(defaults) > 0 && !b.required {
+			arg.SetDefaults(defaults)
+		}
+	} else if value := b.defaultValue(fieldValue); value != "" && !b.required {
+		arg.SetDefault(value)
+	}
+
+	return arg
+}
+
+// inputOption create the option declared by the field
+func (b boundField) inputOption(fieldValue reflect.Value) *option.InputOption {
+	mode := option.Optional
+	_, negatable := b.entries[negatableTagKey]
+
+	switch {
+	case fieldValue.Kind() == reflect.Bool && (negatable || fieldValue.Bool()):
+		// flags enabled by default can only be disabled with --no-<name>
+		mode = option.Negatable
+	case fieldValue.Kind() == reflect.Bool:
+		mode = option.None
+	case b.required:
+		mode = option.Required
+	}
+
+	if fieldValue.Kind() == reflect.Slice {
+		mode |= option.List
+	}
+
+	opt := option.New(b.name, mode).
+		SetShortcut(b.entries[shortcutTagKey]).
+		SetDescription(b.entries[descTagKey]).
+		SetEnv(b.entries[envTagKey])
+
+	if !opt.IsAcceptValue() {
+		if fieldValue.Bool() {
+			opt.SetDefault(option.Defined)
+		}
+
+		return opt
+	}
+
+	opt.SetConstraint(b.constraint())
+
+	if fieldValue.Kind() == reflect.Slice {
+		if defaults := b.defaults(fieldValue); len(defaults) > 0 {
+			opt.SetDefaults(defaults)
+		}
+	} else {
+		opt.SetDefault(b.defaultValue(fieldValue))
+	}
+
+	return opt
+}
+
+// constraint derive the value constraint from the field type and the tag entries
+func (b boundField) constraint() *constraint.Constraint {
+	var c *constraint.Constraint
+	typ := b.field.Type
+
+	if typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+
+	switch {
+	case typ == durationType:
+		c = constraint.Duration()
+	case isIntKind(typ.Kind()) || isUintKind(typ.Kind()):
+		c = constraint.Int()
+	case typ.Kind() == reflect.Float32 || typ.Kind() == reflect.Float64:
+		c = constraint.Float()
+	case typ.Kind() == reflect.Bool:
+		c = constraint.Bool()
+	}
+
+	if enum, ok := b.entries[enumTagKey]; ok {
+		c = constraint.Enum(strings.Split(enum, "|")...)
+	}
+
+	for key, apply := range map[string]func(*constraint.Constraint, float64) *constraint.Constraint{
+		minTagKey: (*constraint.Constraint).Min,
+		maxTagKey: (*constraint.Constraint).Max,
+	} {
+		if raw, ok := b.entries[key]; ok {
+			limit, err := strconv.ParseFloat(raw, 64)
+
+			if err != nil || c == nil {
+				panic(errors.New(fmt.Sprintf("the '%s' tag of the field '%s' is not valid", key, b.field.Name)))
+			}
+
+			apply(c, limit)
+		}
+	}
+
+	return c
+}
+
+// defaultValue return the tag default, or the current field value when not empty
+func (b boundField) defaultValue(fieldValue reflect.Value) string {
+	if value, ok := b.entries[defaultTagKey]; ok {
+		return value
+	}
+
+	if fieldValue.IsZero() {
+		return ""
+	}
+
+	return formatValue(fieldValue)
+}
+
+// defaults return the tag defaults (separated by |), or the current field values
+func (b boundField) defaults(fieldValue reflect.Value) []string {
+	if value, ok := b.entries[defaultTagKey]; ok {
+		return strings.Split(value, "|")
+	}
+
+	values := []string{}
+
+	for i := 0; i < fieldValue.Len(); i++ {
+		values = append(values, formatValue(fieldValue.Index(i)))
+	}
+
+	return values
+}
+
+// set convert the value into the field, empty values keep the field untouched
+func (b boundField) set(fieldValue reflect.Value, value string) {
+	if value == "" {
+		return
+	}
+
+	if err := setValue(fieldValue, value); err != nil {
+		panic(&InvalidValueError{Kind: b.kind, Name: b.name, Value: value, Expected: expectedValue(fieldValue.Type()), Err: err})
+	}
+}
+
+// setList convert the values into the slice field
+func (b boundField) setList(fieldValue reflect.Value, values []string) {
+	list := reflect.MakeSlice(fieldValue.Type(), len(values), len(values))
+
+	for i, value := range values {
+		if err := setValue(list.Index(i), value); err != nil {
+			panic(&InvalidValueError{Kind: b.kind, Name: b.name, Value: value, Expected: expectedValue(fieldValue.Type().Elem()), Err: err})
+		}
+	}
+
+	fieldValue.Set(list)
+}
+
+// setValue convert the string into the value type
+func setValue(value reflect.Value, raw string) error {
+	kind := value.Kind()
+
+	switch {
+	case value.Type() == durationType:
+		duration, err := time.ParseDuration(raw)
+
+		if err != nil {
+			return err
+		}
+
+		value.SetInt(int64(duration))
+	case kind == reflect.String:
+		value.SetString(raw)
+	case kind == reflect.Bool:
+		parsed, err := parseBool(raw)
+
+		if err != nil {
+			return err
+		}
+
+		value.SetBool(parsed)
+	case isIntKind(kind):
+		parsed, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		value.SetInt(parsed)
+	case isUintKind(kind):
+		parsed, err := strconv.ParseUint(raw, 10, value.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		value.SetUint(parsed)
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, value.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		value.SetFloat(parsed)
+	}
+
+	return nil
+}
+
+// formatValue convert the value into its string representation
+func formatValue(value reflect.Value) string {
+	if value.Type() == durationType {
+		return time.Duration(value.Int()).String()
+	}
+
+	return fmt.Sprint(value.Interface())
+}
+
+// expectedValue describe the values accepted by the type
+func expectedValue(typ reflect.Type) string {
+	switch {
+	case typ == durationType:
+		return "a duration"
+	case typ.Kind() == reflect.Bool:
+		return "a boolean"
+	case isIntKind(typ.Kind()) || isUintKind(typ.Kind()):
+		return "an integer"
+	}
+
+	return "a number"
+}
+
+func isSupportedType(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+
+	kind := typ.Kind()
+
+	return kind == reflect.String ||
+		kind == reflect.Bool ||
+		kind == reflect.Float32 ||
+		kind == reflect.Float64 ||
+		isIntKind(kind) ||
+		isUintKind(kind)
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUintKind(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uint64
+}
+
+// kebabCase convert a field name into an option name (e.g. DryRun gives dry-run)
+func kebabCase(name string) string {
+	var builder strings.Builder
+
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			previous := rune(name[i-1])
+			nextLower := i+1 < len(name) && name[i+1] >= 'a' && name[i+1] <= 'z'
+
+			if (previous >= 'a' && previous <= 'z') || (previous >= '0' && previous <= '9') || nextLower {
+				builder.WriteRune('-')
+			}
+		}
+
+		builder.WriteRune(r)
+	}
+
+	return strings.ToLower(builder.String())
+}
@@ -0,0 +1,206 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DrSmithFr/go-console/input/option"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InvalidValueError is raised by typed accessors when an argument or option value cannot be converted
+type InvalidValueError struct {
+	Kind     string // "argument" or "option"
+	Name     string
+	Value    string
+	Expected string
+	Err      error
+}
+
+func (e *InvalidValueError) Error() string {
+	name := e.Name
+
+	if e.Kind == "option" {
+		name = "--" + name
+	}
+
+	return fmt.Sprintf("the '%s' %s expects %s, '%s' given", name, e.Kind, e.Expected, e.Value)
+}
+
+func (e *InvalidValueError) Unwrap() error {
+	return e.Err
+}
+
+// Returns the argument value converted to an int
+func (i *abstractInput) ArgumentInt(name string) int {
+	return convert("argument", name, i.Argument(name), "an integer", strconv.Atoi)
+}
+
+// Returns the argument value converted to a float
+func (i *abstractInput) ArgumentFloat(name string) float64 {
+	return convert("argument", name, i.Argument(name), "a number", parseFloat)
+}
+
+// Returns the argument value converted to a bool (true, false, yes, no, on, off, 1 or 0)
+func (i *abstractInput) ArgumentBool(name string) bool {
+	return convert("argument", name, i.Argument(name), "a boolean", parseBool)
+}
+
+// Returns the argument value converted to a duration (e.g. 1h30m)
+func (i *abstractInput) ArgumentDuration(name string) time.Duration {
+	return convert("argument", name, i.Argument(name), "a duration", time.ParseDuration)
+}
+
+// Returns the argument value converted to a time using the given layout (e.g. time.RFC3339)
+func (i *abstractInput) ArgumentTime(name string, layout string) time.Time {
+	return convert("argument", name, i.Argument(name), "a time formatted as "+layout, parseTime(layout))
+}
+
+// Returns the argument array value converted to ints
+func (i *abstractInput) ArgumentIntList(name string) []int {
+	return convertList("argument", name, i.ArgumentList(name), "integers", strconv.Atoi)
+}
+
+// Returns the argument array value converted to floats
+func (i *abstractInput) ArgumentFloatList(name string) []float64 {
+	return convertList("argument", name, i.ArgumentList(name), "numbers", parseFloat)
+}
+
+// Returns the argument array value converted to durations
+func (i *abstractInput) ArgumentDurationList(name string) []time.Duration {
+	return convertList("argument", name, i.ArgumentList(name), "durations", time.ParseDuration)
+}
+
+// Returns the option value converted to an int
+func (i *abstractInput) OptionInt(name string) int {
+	return convert("option", name, i.Option(name), "an integer", strconv.Atoi)
+}
+
+// Returns the option value converted to a float
+func (i *abstractInput) OptionFloat(name string) float64 {
+	return convert("option", name, i.Option(name), "a number", parseFloat)
+}
+
+// Returns the option value converted to a bool, flags without value are true when given
+func (i *abstractInput) OptionBool(name string) bool {
+	value := i.Option(name)
+
+	if i.definition.Option(name).IsCount() {
+		return i.OptionCount(name) > 0
+	}
+
+	if !i.definition.Option(name).IsAcceptValue() {
+		return value == option.Defined
+	}
+
+	return convert("option", name, value, "a boolean", parseBool)
+}
+
+// Returns the number of occurrences of a Count option (e.g. -vvv gives 3)
+func (i *abstractInput) OptionCount(name string) int {
+	value := i.Option(name)
+
+	if !i.definition.Option(name).IsCount() {
+		panic(errors.New(fmt.Sprintf("the '%s' option is not a Count option, use OptionInt() instead", name)))
+	}
+
+	return convert("option", name, value, "a number of occurrences", strconv.Atoi)
+}
+
+// Returns the state of a flag: nil when neither given nor configured, true when enabled and false when disabled (e.g. --no-cache)
+func (i *abstractInput) OptionNegatable(name string) *bool {
+	value := i.Option(name)
+
+	if i.definition.Option(name).IsAcceptValue() {
+		panic(errors.New(fmt.Sprintf("the '%s' option accepts a value, use OptionBool() instead", name)))
+	}
+
+	if _, source := i.optionValue(i.definition.Option(name)); source == SourceDefault {
+		return nil
+	}
+
+	enabled := value == option.Defined
+
+	return &enabled
+}
+
+// Returns the option value converted to a duration (e.g. 1h30m)
+func (i *abstractInput) OptionDuration(name string) time.Duration {
+	return convert("option", name, i.Option(name), "a duration", time.ParseDuration)
+}
+
+// Returns the option value converted to a time using the given layout (e.g. time.RFC3339)
+func (i *abstractInput) OptionTime(name string, layout string) time.Time {
+	return convert("option", name, i.Option(name), "a time formatted as "+layout, parseTime(layout))
+}
+
+// Returns the option array value converted to ints
+func (i *abstractInput) OptionIntList(name string) []int {
+	return convertList("option", name, i.OptionList(name), "integers", strconv.Atoi)
+}
+
+// Returns the option array value converted to floats
+func (i *abstractInput) OptionFloatList(name string) []float64 {
+	return convertList("option", name, i.OptionList(name), "numbers", parseFloat)
+}
+
+// Returns the option array value converted to durations
+func (i *abstractInput) OptionDurationList(name string) []time.Duration {
+	return convertList("option", name, i.OptionList(name), "durations", time.ParseDuration)
+}
+
+// convert the value, an empty value gives the zero value
+func convert[T any](kind string, name string, value string, expected string, parse func(string) (T, error)) T {
+	var converted T
+
+	if value == "" {
+		return converted
+	}
+
+	converted, err := parse(value)
+
+	if err != nil {
+		panic(&InvalidValueError{Kind: kind, Name: name, Value: value, Expected: expected, Err: err})
+	}
+
+	return converted
+}
+
+// convert every value of the list
+func convertList[T any](kind string, name string, values []string, expected string, parse func(string) (T, error)) []T {
+	converted := make([]T, 0, len(values))
+
+	for _, value := range values {
+		item, err := parse(value)
+
+		if err != nil {
+			panic(&InvalidValueError{Kind: kind, Name: name, Value: value, Expected: expected, Err: err})
+		}
+
+		converted = append(converted, item)
+	}
+
+	return converted
+}
+
+func parseFloat(value string) (float64, error) {
+	return strconv.ParseFloat(value, 64)
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "1", "true", "yes", "on":
+		return true, nil
+	case "0", "false", "no", "off":
+		return false, nil
+	}
+
+	return false, errors.New(fmt.Sprintf("invalid boolean '%s'", value))
+}
+
+func parseTime(layout string) func(string) (time.Time, error) {
+	return func(value string) (time.Time, error) {
+		return time.Parse(layout, value)
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"github.com/DrSmithFr/go-console/helper"
+	"github.com/DrSmithFr/go-console/input/completion"
+	"github.com/DrSmithFr/go-console/input/constraint"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -15,6 +18,12 @@ const (
 	Required = 2
 	Optional = 4
 	List     = 8
+
+	// Negatable flags can be disabled with --no-<name> (e.g. --cache / --no-cache)
+	Negatable = 16
+
+	// Count flags can be repeated, their value is the number of occurrences (e.g. -vvv gives 3)
+	Count = 32
 )
 
 const (
@@ -35,10 +44,26 @@ func New(
 		panic(errors.New("an option name cannot be empty"))
 	}
 
-	if mode > 15 || mode < 1 {
+	if mode > 63 || mode < 1 {
 		panic(errors.New(fmt.Sprintf("option mode '%d' is not valid", mode)))
 	}
 
+	if Negatable == (Negatable & mode) {
+		if 0 != mode&(Required|Optional|List) {
+			panic(errors.New("impossible to have an option mode Negatable if the option accepts a value"))
+		}
+
+		mode |= None
+	}
+
+	if Count == (Count & mode) {
+		if 0 != mode&(Required|Optional|List|Negatable) {
+			panic(errors.New("impossible to have an option mode Count if the option accepts a value or is Negatable"))
+		}
+
+		mode |= None
+	}
+
 	opt := &InputOption{
 		name:          name,
 		shortcut:      "",
@@ -63,6 +88,9 @@ type InputOption struct {
 	defaultValue  string
 	defaultValues []string
 	description   string
+	env           string
+	constraint    *constraint.Constraint
+	completion    completion.Provider
 }
 
 // Returns the option name.
@@ -127,12 +155,30 @@ func (a *InputOption) IsList() bool {
 	return List == (List & a.mode)
 }
 
+// returns true if the option value is its number of occurrences.
+func (a *InputOption) IsCount() bool {
+	return Count == (Count & a.mode)
+}
+
+// returns true if the option can be disabled with --no-<name>.
+func (a *InputOption) IsNegatable() bool {
+	return Negatable == (Negatable & a.mode)
+}
+
 // Sets the default value.
 func (a *InputOption) SetDefault(defaultValue string) *InputOption {
-	if !a.IsAcceptValue() && "" != defaultValue {
+	if !a.IsAcceptValue() && "" != defaultValue && !a.IsNegatable() && !a.IsCount() {
 		panic(errors.New("cannot set a default value when using InputOption::VALUE_NONE mode"))
 	}
 
+	if a.IsNegatable() && "" != defaultValue && Defined != defaultValue && Undefined != defaultValue {
+		panic(errors.New("the default value of a Negatable option must be option.Defined or option.Undefined"))
+	}
+
+	if _, err := strconv.Atoi(defaultValue); a.IsCount() && "" != defaultValue && err != nil {
+		panic(errors.New("the default value of a Count option must be a number"))
+	}
+
 	if a.IsList() {
 		panic(errors.New("cannot use SetDefaultAnswer() for InputOption::VALUE_IS_ARRAY mode, use SetDefaults() instead"))
 	}
@@ -196,3 +242,57 @@ func (a *InputOption) Equals(b InputOption) bool {
 		b.IsValueRequired() == a.IsValueRequired() &&
 		b.IsValueOptional() == a.IsValueOptional()
 }
+
+// Sets the provider of candidate values for shell completion and prompts.
+func (a *InputOption) SetCompletion(provider completion.Provider) *InputOption {
+	a.completion = provider
+	return a
+}
+
+// Returns the provider of candidate values (nil when not defined).
+func (a *InputOption) Completion() completion.Provider {
+	return a.completion
+}
+
+// Returns the candidate values for the partially typed value.
+func (a *InputOption) Complete(value string, input completion.Input) []string {
+	if a.completion == nil && a.constraint != nil && len(a.constraint.AllowedValues()) > 0 {
+		return completion.Values(a.constraint.AllowedValues()...)(value, input)
+	}
+
+	if a.completion == nil {
+		return []string{}
+	}
+
+	return a.completion(value, input)
+}
+
+// Sets the environment variable used when the option is not given on the command line.
+func (a *InputOption) SetEnv(name string) *InputOption {
+	a.env = name
+	return a
+}
+
+// Returns the environment variable name (empty when not defined).
+func (a *InputOption) Env() string {
+	return a.env
+}
+
+// Sets the value type and constraints, checked when the input is validated.
+func (a *InputOption) SetConstraint(c *constraint.Constraint) *InputOption {
+	if !a.IsAcceptValue() && c != nil {
+		panic(errors.New("cannot set a constraint when using InputOption::None mode"))
+	}
+
+	if c != nil {
+		c.Check()
+	}
+
+	a.constraint = c
+	return a
+}
+
+// Returns the value type and constraints (nil when not defined).
+func (a *InputOption) Constraint() *constraint.Constraint {
+	return a.constraint
+}
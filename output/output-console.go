@@ -5,13 +5,40 @@ import (
 	"fmt"
 	"github.com/DrSmithFr/go-console/formatter"
 	"github.com/DrSmithFr/go-console/verbosity"
+	"golang.org/x/term"
+	"io"
 	"os"
 )
 
-// constructor
+// constructor, messages are written to stdout and errors to stderr,
+// the decoration of each is detected (see DetectDecoration)
+func NewConsoleOutput(format *formatter.OutputFormatter) *ConsoleOutput {
+	out := newStreamOutput(os.Stdout, DetectDecoration(os.Stdout), format)
+
+	// clone the formatter to decorate the error output on its own
+	errorFormat := *out.formatter
+	out.errorOutput = newStreamOutput(os.Stderr, DetectDecoration(os.Stderr), &errorFormat)
+
+	return out
+}
+
+// constructor, messages are written to stdout and errors to stderr
 func NewCliOutput(decorated bool, format *formatter.OutputFormatter) *ConsoleOutput {
+	out := newStreamOutput(os.Stdout, decorated, format)
+
+	// clone the formatter to decorate the error output on its own
+	errorFormat := *out.formatter
+
+	// the error output is only decorated when stderr supports it
+	out.errorOutput = newStreamOutput(os.Stderr, decorated && DetectDecoration(os.Stderr), &errorFormat)
+
+	return out
+}
+
+func newStreamOutput(stream io.Writer, decorated bool, format *formatter.OutputFormatter) *ConsoleOutput {
 	out := new(ConsoleOutput)
 
+	out.stream = stream
 	out.doPrint = out.StdOut
 	out.doWrite = out.StdOutBytes
 
@@ -29,9 +56,12 @@ func NewCliOutput(decorated bool, format *formatter.OutputFormatter) *ConsoleOut
 // Console output classes
 type ConsoleOutput struct {
 	NullOutput
+	stream      io.Writer
+	errorOutput OutputInterface
+	sections    []*ConsoleSectionOutput
 }
 
-var _ OutputInterface = (*ConsoleOutput)(nil)
+var _ ConsoleOutputInterface = (*ConsoleOutput)(nil)
 
 func (o *ConsoleOutput) StdOut(message string, level verbosity.Level) {
 	if o.IsQuiet() {
@@ -39,7 +69,7 @@ func (o *ConsoleOutput) StdOut(message string, level verbosity.Level) {
 	}
 
 	if o.IsVerbosityAllowed(level) {
-		fmt.Printf(message)
+		fmt.Fprint(o.stream, message)
 	}
 }
 
@@ -48,5 +78,33 @@ func (o *ConsoleOutput) StdOutBytes(p []byte) (n int, err error) {
 		return 0, errors.New("console output is quiet")
 	}
 
-	return fmt.Fprint(os.Stdout, string(p))
+	return fmt.Fprint(o.stream, string(p))
+}
+
+// Gets the output used for errors (the output itself when it has none)
+func (o *ConsoleOutput) ErrorOutput() OutputInterface {
+	if nil == o.errorOutput {
+		return o
+	}
+
+	return o.errorOutput
+}
+
+// Sets the output used for errors
+func (o *ConsoleOutput) SetErrorOutput(out OutputInterface) {
+	o.errorOutput = out
+}
+
+// Sets the verbosity of the output and of the error output
+func (o *ConsoleOutput) SetVerbosity(level verbosity.Level) {
+	o.NullOutput.SetVerbosity(level)
+
+	if nil != o.errorOutput {
+		o.errorOutput.SetVerbosity(level)
+	}
+}
+
+// isTerminal returns true when the file is a terminal (not piped or redirected)
+func isTerminal(file *os.File) bool {
+	return term.IsTerminal(int(file.Fd()))
 }
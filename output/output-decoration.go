@@ -0,0 +1,62 @@
+package output
+
+import (
+	"os"
+	"strings"
+)
+
+// continuous integration services displaying ANSI colors, their output is not a terminal
+var colorCiVariables = []string{"GITHUB_ACTIONS", "GITLAB_CI", "BUILDKITE", "CIRCLECI"}
+
+// DetectDecoration returns true when the messages written to the file can be decorated.
+// FORCE_COLOR and CLICOLOR_FORCE force the decoration, NO_COLOR, CLICOLOR=0 and TERM=dumb disable it,
+// otherwise the file must be a terminal (or the output of a CI service displaying colors)
+func DetectDecoration(file *os.File) bool {
+	if force, ok := lookupEnv("FORCE_COLOR"); ok {
+		return isEnabled(force)
+	}
+
+	if _, ok := lookupEnv("NO_COLOR"); ok {
+		return false
+	}
+
+	if force, ok := lookupEnv("CLICOLOR_FORCE"); ok && isEnabled(force) {
+		return true
+	}
+
+	if color, ok := lookupEnv("CLICOLOR"); ok && !isEnabled(color) {
+		return false
+	}
+
+	if "dumb" == os.Getenv("TERM") {
+		return false
+	}
+
+	if nil != file && isTerminal(file) {
+		return true
+	}
+
+	for _, name := range colorCiVariables {
+		if _, ok := lookupEnv(name); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lookupEnv returns the value of a non-empty environment variable
+func lookupEnv(name string) (string, bool) {
+	value := os.Getenv(name)
+	return value, "" != value
+}
+
+// isEnabled returns false for the values disabling a setting (0, false, no, off)
+func isEnabled(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "0", "false", "no", "off":
+		return false
+	}
+
+	return true
+}
@@ -55,3 +55,14 @@ type OutputInterface interface {
 	// Implements io.Writer
 	Write(p []byte) (n int, err error)
 }
+
+// ConsoleOutputInterface is the interface implemented by the outputs having a separate error output (e.g. stderr)
+type ConsoleOutputInterface interface {
+	OutputInterface
+
+	// Gets the output used for errors.
+	ErrorOutput() OutputInterface
+
+	// Sets the output used for errors.
+	SetErrorOutput(out OutputInterface)
+}
@@ -0,0 +1,206 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DrSmithFr/go-console/helper"
+	"github.com/DrSmithFr/go-console/verbosity"
+	"golang.org/x/term"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Section creates an independent region of the output,
+// which can be overwritten, cleared or appended to while other sections are updated
+func (o *ConsoleOutput) Section() *ConsoleSectionOutput {
+	section := new(ConsoleSectionOutput)
+
+	section.stream = o.stream
+	section.errorOutput = o.ErrorOutput()
+	section.sections = &o.sections
+	section.width = terminalWidth(o.stream)
+	section.doPrint = section.SectionOut
+	section.doWrite = section.SectionOutBytes
+	section.formatter = o.formatter
+	section.verbosity = o.verbosity
+
+	o.sections = append(o.sections, section)
+
+	return section
+}
+
+// Console section output classes
+type ConsoleSectionOutput struct {
+	NullOutput
+	stream      io.Writer
+	errorOutput OutputInterface
+
+	// the lines written to the section (newline included)
+	content []string
+
+	// the number of terminal lines taken by the content (wrapped lines included)
+	lines int
+
+	// all the sections of the console output, the oldest first
+	sections *[]*ConsoleSectionOutput
+
+	// the terminal width, used to count wrapped lines
+	width int
+}
+
+var _ ConsoleOutputInterface = (*ConsoleSectionOutput)(nil)
+
+func (o *ConsoleSectionOutput) SectionOut(message string, level verbosity.Level) {
+	if o.IsQuiet() || !o.IsVerbosityAllowed(level) {
+		return
+	}
+
+	if !o.IsDecorated() {
+		// without ANSI codes the cursor cannot move, the section is a plain output
+		fmt.Fprint(o.stream, message)
+		return
+	}
+
+	// a section always ends with a newline to keep the cursor math simple
+	if !strings.HasSuffix(message, "\n") {
+		message += "\n"
+	}
+
+	erased := o.popStreamContentUntilCurrentSection(0)
+	o.addContent(message)
+
+	fmt.Fprint(o.stream, message+erased)
+}
+
+func (o *ConsoleSectionOutput) SectionOutBytes(p []byte) (n int, err error) {
+	if o.IsQuiet() {
+		return 0, errors.New("console output is quiet")
+	}
+
+	o.SectionOut(string(p), verbosity.Normal)
+
+	return len(p), nil
+}
+
+// Content returns the text written to the section
+func (o *ConsoleSectionOutput) Content() string {
+	return strings.Join(o.content, "")
+}
+
+// Clear erases the whole content of the section
+func (o *ConsoleSectionOutput) Clear() {
+	o.ClearLines(0)
+}
+
+// ClearLines erases the last lines of the section (all of them when lines <= 0)
+func (o *ConsoleSectionOutput) ClearLines(lines int) {
+	if len(o.content) == 0 || !o.IsDecorated() {
+		return
+	}
+
+	var removed []string
+
+	if lines > 0 && lines < len(o.content) {
+		removed = o.content[len(o.content)-lines:]
+		o.content = o.content[:len(o.content)-lines]
+	} else {
+		removed = o.content
+		o.content = nil
+	}
+
+	count := 0
+
+	for _, line := range removed {
+		count += o.lineCount(line)
+	}
+
+	o.lines -= count
+
+	fmt.Fprint(o.stream, o.popStreamContentUntilCurrentSection(count))
+}
+
+// Overwrite replaces the content of the section by the message
+func (o *ConsoleSectionOutput) Overwrite(message string) {
+	o.Clear()
+	o.Println(message)
+}
+
+// Gets the output used for errors
+func (o *ConsoleSectionOutput) ErrorOutput() OutputInterface {
+	if nil == o.errorOutput {
+		return o
+	}
+
+	return o.errorOutput
+}
+
+// Sets the output used for errors
+func (o *ConsoleSectionOutput) SetErrorOutput(out OutputInterface) {
+	o.errorOutput = out
+}
+
+// addContent stores the lines of the message and counts the terminal lines they take
+func (o *ConsoleSectionOutput) addContent(message string) {
+	for _, line := range strings.SplitAfter(message, "\n") {
+		if line == "" {
+			continue
+		}
+
+		o.content = append(o.content, line)
+		o.lines += o.lineCount(line)
+	}
+}
+
+// lineCount returns the number of terminal lines taken by a line, once wrapped
+func (o *ConsoleSectionOutput) lineCount(line string) int {
+	length := helper.StrlenWithoutDecoration(o.formatter, strings.TrimSuffix(line, "\n"))
+
+	if length == 0 || o.width <= 0 {
+		return 1
+	}
+
+	return (length + o.width - 1) / o.width
+}
+
+// popStreamContentUntilCurrentSection erases the sections written after this one
+// (and the given number of lines of this one), returning the erased content to write it back
+func (o *ConsoleSectionOutput) popStreamContentUntilCurrentSection(linesToClear int) string {
+	var erased []string
+
+	sections := *o.sections
+
+	for index := len(sections) - 1; index >= 0; index-- {
+		section := sections[index]
+
+		if section == o {
+			break
+		}
+
+		linesToClear += section.lines
+		erased = append([]string{section.Content()}, erased...)
+	}
+
+	if linesToClear > 0 {
+		// move the cursor up and erase down to the end of the screen
+		fmt.Fprintf(o.stream, "\x1b[%dA\x1b[0J", linesToClear)
+	}
+
+	return strings.Join(erased, "")
+}
+
+// terminalWidth returns the width of the terminal, falling back on COLUMNS then 80
+func terminalWidth(stream io.Writer) int {
+	if file, ok := stream.(*os.File); ok {
+		if width, _, err := term.GetSize(int(file.Fd())); err == nil && width > 0 {
+			return width
+		}
+	}
+
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+
+	return 80
+}
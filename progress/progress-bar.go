@@ -0,0 +1,523 @@
+package progress
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DrSmithFr/go-console/helper"
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/DrSmithFr/go-console/verbosity"
+	"math"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// predefined formats, the "_nomax" variants are used when the maximum is unknown
+const (
+	FormatNormal      = "normal"
+	FormatVerbose     = "verbose"
+	FormatVeryVerbose = "very_verbose"
+	FormatDebug       = "debug"
+)
+
+var formats = map[string]string{
+	"normal":       " %current%/%max% [%bar%] %percent:3s%%",
+	"normal_nomax": " %current% [%bar%]",
+
+	"verbose":       " %current%/%max% [%bar%] %percent:3s%% %elapsed:6s%",
+	"verbose_nomax": " %current% [%bar%] %elapsed:6s%",
+
+	"very_verbose":       " %current%/%max% [%bar%] %percent:3s%% %elapsed:6s%/%estimated:-6s%",
+	"very_verbose_nomax": " %current% [%bar%] %elapsed:6s%",
+
+	"debug":       " %current%/%max% [%bar%] %percent:3s%% %elapsed:6s%/%estimated:-6s% %memory:6s%",
+	"debug_nomax": " %current% [%bar%] %elapsed:6s% %memory:6s%",
+}
+
+// %name% or %name:sprintf-format% (e.g. %percent:3s%)
+var placeholderRegex = regexp.MustCompile(`(?i)%([a-z\-_]+)(?::([^%]+))?%`)
+
+// A PlaceholderFormatter returns the text of a placeholder for the current state of the bar
+type PlaceholderFormatter func(bar *ProgressBar) string
+
+var placeholderFormatters = map[string]PlaceholderFormatter{
+	"bar": func(bar *ProgressBar) string {
+		complete := bar.BarOffset()
+		display := strings.Repeat(bar.barChar, complete)
+
+		if complete < bar.barWidth {
+			empty := bar.barWidth - complete - helper.Strlen(bar.progressChar)
+			display += bar.progressChar
+
+			if empty > 0 {
+				display += strings.Repeat(bar.emptyBarChar, empty)
+			}
+		}
+
+		return display
+	},
+	"elapsed": func(bar *ProgressBar) string {
+		return helper.FormatDuration(time.Since(bar.startTime))
+	},
+	"remaining": func(bar *ProgressBar) string {
+		if bar.max == 0 {
+			panic(errors.New("unable to display the remaining time if the maximum number of steps is not set"))
+		}
+
+		return helper.FormatDuration(bar.Remaining())
+	},
+	"estimated": func(bar *ProgressBar) string {
+		if bar.max == 0 {
+			panic(errors.New("unable to display the estimated time if the maximum number of steps is not set"))
+		}
+
+		return helper.FormatDuration(bar.Estimated())
+	},
+	"memory": func(bar *ProgressBar) string {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+
+		return helper.FormatMemory(stats.Alloc)
+	},
+	"current": func(bar *ProgressBar) string {
+		return fmt.Sprintf("%*d", bar.stepWidth, bar.step)
+	},
+	"max": func(bar *ProgressBar) string {
+		return fmt.Sprintf("%d", bar.max)
+	},
+	"percent": func(bar *ProgressBar) string {
+		if bar.max == 0 {
+			return "0"
+		}
+
+		return fmt.Sprintf("%d", bar.step*100/bar.max)
+	},
+}
+
+// SetPlaceholderFormatter defines a placeholder available in the format of every bar
+func SetPlaceholderFormatter(name string, formatter PlaceholderFormatter) {
+	placeholderFormatters[name] = formatter
+}
+
+// SetFormatDefinition defines a format available to every bar (e.g. "minimal")
+func SetFormatDefinition(name string, format string) {
+	formats[name] = format
+}
+
+// A ProgressBar displays the progress of a task on the output
+type ProgressBar struct {
+	output output.OutputInterface
+
+	max       int
+	step      int
+	stepWidth int
+	percent   float64
+	startTime time.Time
+
+	barWidth     int
+	barChar      string
+	emptyBarChar string
+	progressChar string
+
+	format     string
+	formatName string
+	messages   map[string]string
+	formatters map[string]PlaceholderFormatter
+
+	overwrite       bool
+	previousMessage *string
+	lastWriteTime   time.Time
+
+	redrawFreq               int
+	minSecondsBetweenRedraws time.Duration
+	maxSecondsBetweenRedraws time.Duration
+}
+
+// constructor, a max of 0 displays a bar without known maximum
+func NewProgressBar(out output.OutputInterface, max int) *ProgressBar {
+	bar := &ProgressBar{
+		output:       out,
+		startTime:    time.Now(),
+		barWidth:     28,
+		barChar:      "=",
+		emptyBarChar: "-",
+		progressChar: ">",
+		messages:     map[string]string{},
+		formatters:   map[string]PlaceholderFormatter{},
+		overwrite:    true,
+
+		minSecondsBetweenRedraws: 40 * time.Millisecond,
+		maxSecondsBetweenRedraws: time.Second,
+	}
+
+	bar.SetMaxSteps(max)
+
+	if !out.IsDecorated() {
+		// without ANSI codes the bar is written on a new line at each redraw,
+		// redrawing every 10% of the progress only
+		bar.overwrite = false
+		bar.minSecondsBetweenRedraws = 0
+		bar.maxSecondsBetweenRedraws = math.MaxInt64
+	}
+
+	return bar
+}
+
+func (b *ProgressBar) SetMaxSteps(max int) *ProgressBar {
+	if max < 0 {
+		max = 0
+	}
+
+	// the format is resolved again, the maximum may now be unknown
+	b.format = ""
+	b.max = max
+	b.updateStepWidth()
+
+	return b
+}
+
+// updateStepWidth pads %current% to the width of the maximum, when unknown the width
+// only grows with the steps so that the display does not move back
+func (b *ProgressBar) updateStepWidth() {
+	if b.max > 0 {
+		b.stepWidth = len(fmt.Sprintf("%d", b.max))
+		return
+	}
+
+	if width := len(fmt.Sprintf("%d", b.step)); width > b.stepWidth {
+		b.stepWidth = width
+	}
+
+	if b.stepWidth < 4 {
+		b.stepWidth = 4
+	}
+}
+
+func (b *ProgressBar) MaxSteps() int {
+	return b.max
+}
+
+func (b *ProgressBar) Progress() int {
+	return b.step
+}
+
+// ProgressPercent returns the progress between 0 and 1
+func (b *ProgressBar) ProgressPercent() float64 {
+	return b.percent
+}
+
+func (b *ProgressBar) StartTime() time.Time {
+	return b.startTime
+}
+
+// BarOffset returns the number of complete bar characters
+func (b *ProgressBar) BarOffset() int {
+	if b.max > 0 {
+		return int(math.Floor(b.percent * float64(b.barWidth)))
+	}
+
+	// without maximum, the progress character loops over the bar
+	return b.step % b.barWidth
+}
+
+// Estimated returns the estimated duration of the whole task
+func (b *ProgressBar) Estimated() time.Duration {
+	if b.step == 0 {
+		return 0
+	}
+
+	return time.Duration(float64(time.Since(b.startTime)) / float64(b.step) * float64(b.max))
+}
+
+// Remaining returns the estimated duration until the end of the task
+func (b *ProgressBar) Remaining() time.Duration {
+	if b.step == 0 {
+		return 0
+	}
+
+	return time.Duration(float64(time.Since(b.startTime)) / float64(b.step) * float64(b.max-b.step))
+}
+
+func (b *ProgressBar) SetBarWidth(width int) *ProgressBar {
+	if width < 1 {
+		width = 1
+	}
+
+	b.barWidth = width
+	return b
+}
+
+func (b *ProgressBar) BarWidth() int {
+	return b.barWidth
+}
+
+func (b *ProgressBar) SetBarCharacter(char string) *ProgressBar {
+	b.barChar = char
+	return b
+}
+
+func (b *ProgressBar) SetEmptyBarCharacter(char string) *ProgressBar {
+	b.emptyBarChar = char
+	return b
+}
+
+func (b *ProgressBar) SetProgressCharacter(char string) *ProgressBar {
+	b.progressChar = char
+	return b
+}
+
+// SetFormat sets a predefined format name (e.g. FormatVerbose) or a custom format,
+// by default the format depends on the verbosity of the output
+func (b *ProgressBar) SetFormat(format string) *ProgressBar {
+	b.format = ""
+	b.formatName = format
+	return b
+}
+
+// SetMessage sets the text of the %message% placeholder
+func (b *ProgressBar) SetMessage(message string) *ProgressBar {
+	return b.SetNamedMessage("message", message)
+}
+
+// SetNamedMessage sets the text of a custom placeholder (e.g. %filename%)
+func (b *ProgressBar) SetNamedMessage(name string, message string) *ProgressBar {
+	b.messages[name] = message
+	return b
+}
+
+func (b *ProgressBar) Message(name string) string {
+	return b.messages[name]
+}
+
+// SetPlaceholderFormatter defines a placeholder for this bar only
+func (b *ProgressBar) SetPlaceholderFormatter(name string, formatter PlaceholderFormatter) *ProgressBar {
+	b.formatters[name] = formatter
+	return b
+}
+
+// SetRedrawFrequency redraws the bar every given steps (by default every 10% of the max, or every step)
+func (b *ProgressBar) SetRedrawFrequency(freq int) *ProgressBar {
+	b.redrawFreq = freq
+	return b
+}
+
+// MinSecondsBetweenRedraws skips the redraws happening sooner than the duration after the previous one
+func (b *ProgressBar) MinSecondsBetweenRedraws(d time.Duration) *ProgressBar {
+	b.minSecondsBetweenRedraws = d
+	return b
+}
+
+// MaxSecondsBetweenRedraws forces a redraw when the duration is elapsed since the previous one
+func (b *ProgressBar) MaxSecondsBetweenRedraws(d time.Duration) *ProgressBar {
+	b.maxSecondsBetweenRedraws = d
+	return b
+}
+
+// SetOverwrite sets whether the bar is redrawn in place or on a new line
+func (b *ProgressBar) SetOverwrite(overwrite bool) *ProgressBar {
+	b.overwrite = overwrite
+	return b
+}
+
+// Start starts the progress and displays the bar, a max > 0 replaces the maximum
+func (b *ProgressBar) Start(max int) {
+	b.startTime = time.Now()
+	b.step = 0
+	b.percent = 0
+
+	if max > 0 {
+		b.SetMaxSteps(max)
+	}
+
+	b.Display()
+}
+
+// Advance advances the progress by the given number of steps (usually 1)
+func (b *ProgressBar) Advance(step int) {
+	b.SetProgress(b.step + step)
+}
+
+// SetProgress sets the current step and redraws the bar when needed
+func (b *ProgressBar) SetProgress(step int) {
+	if b.max > 0 && step > b.max {
+		b.max = step
+	} else if step < 0 {
+		step = 0
+	}
+
+	freq := b.redrawFreq
+
+	if freq <= 0 {
+		freq = b.max / 10
+
+		if freq < 1 {
+			freq = 1
+		}
+	}
+
+	previousPeriod := b.step / freq
+	currentPeriod := step / freq
+
+	b.step = step
+	b.percent = 0
+	b.updateStepWidth()
+
+	if b.max > 0 {
+		b.percent = float64(step) / float64(b.max)
+	}
+
+	interval := time.Since(b.lastWriteTime)
+
+	// the finished bar is always drawn
+	if b.max > 0 && step == b.max {
+		b.Display()
+		return
+	}
+
+	if interval < b.minSecondsBetweenRedraws {
+		return
+	}
+
+	if previousPeriod != currentPeriod || interval >= b.maxSecondsBetweenRedraws {
+		b.Display()
+	}
+}
+
+// Finish completes the progress, the maximum becomes the current step when it is unknown
+func (b *ProgressBar) Finish() {
+	if b.max == 0 {
+		b.SetMaxSteps(b.step)
+	}
+
+	if b.step == b.max && !b.overwrite {
+		// already displayed, prevents a second 100% line
+		return
+	}
+
+	b.SetProgress(b.max)
+}
+
+// Display draws the bar, hidden when the output is quiet
+func (b *ProgressBar) Display() {
+	if b.output.IsQuiet() {
+		return
+	}
+
+	b.write(b.buildLine())
+}
+
+// Clear removes the bar from the output (when it is redrawn in place)
+func (b *ProgressBar) Clear() {
+	if !b.overwrite {
+		return
+	}
+
+	b.write("")
+}
+
+// write replaces the previous bar by the message
+func (b *ProgressBar) write(message string) {
+	if b.previousMessage != nil && *b.previousMessage == message {
+		return
+	}
+
+	original := message
+	section, inSection := b.output.(*output.ConsoleSectionOutput)
+
+	if b.overwrite {
+		if b.previousMessage != nil && *b.previousMessage != "" {
+			lines := strings.Count(*b.previousMessage, "\n")
+
+			if inSection {
+				section.ClearLines(lines + 1)
+			} else {
+				// move back to the beginning of the bar and erase it
+				message = "\x1b[1G\x1b[2K" + strings.Repeat("\x1b[1A\x1b[2K", lines) + message
+			}
+		}
+	} else if b.step > 0 {
+		message = "\n" + message
+	}
+
+	b.previousMessage = &original
+	b.lastWriteTime = time.Now()
+
+	if inSection && b.overwrite {
+		// a section keeps its lines ended by a newline
+		if original != "" {
+			section.Println(original)
+		}
+
+		return
+	}
+
+	b.output.Print(message)
+}
+
+// buildLine replaces the placeholders of the format by their values
+func (b *ProgressBar) buildLine() string {
+	return placeholderRegex.ReplaceAllStringFunc(b.realFormat(), func(match string) string {
+		parts := placeholderRegex.FindStringSubmatch(match)
+		text, found := b.placeholder(parts[1])
+
+		if !found {
+			return match
+		}
+
+		if parts[2] != "" {
+			return fmt.Sprintf("%"+parts[2], text)
+		}
+
+		return text
+	})
+}
+
+// placeholder returns the text of a placeholder, from the bar formatters, the global ones or the messages
+func (b *ProgressBar) placeholder(name string) (string, bool) {
+	if formatter, ok := b.formatters[name]; ok {
+		return formatter(b), true
+	}
+
+	if formatter, ok := placeholderFormatters[name]; ok {
+		return formatter(b), true
+	}
+
+	if message, ok := b.messages[name]; ok {
+		return message, true
+	}
+
+	return "", false
+}
+
+// realFormat resolves the format, depending on the verbosity and whether the maximum is known
+func (b *ProgressBar) realFormat() string {
+	if b.format != "" {
+		return b.format
+	}
+
+	name := b.formatName
+
+	if name == "" {
+		switch level := b.output.Verbosity(); {
+		case level >= verbosity.Debug:
+			name = FormatDebug
+		case level >= verbosity.VeryVerbose:
+			name = FormatVeryVerbose
+		case level >= verbosity.Verbose:
+			name = FormatVerbose
+		default:
+			name = FormatNormal
+		}
+	}
+
+	if format, ok := formats[name+"_nomax"]; ok && b.max == 0 {
+		b.format = format
+	} else if format, ok := formats[name]; ok {
+		b.format = format
+	} else {
+		b.format = name
+	}
+
+	return b.format
+}
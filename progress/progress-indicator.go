@@ -0,0 +1,353 @@
+package progress
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DrSmithFr/go-console/helper"
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/DrSmithFr/go-console/verbosity"
+	"sync"
+	"time"
+)
+
+// predefined indicator formats, the "_no_ansi" variants are used when the output is not decorated
+var indicatorFormats = map[string]string{
+	"normal":         " %indicator% %message%",
+	"normal_no_ansi": " %message%",
+
+	"verbose":         " %indicator% %message% (%elapsed:6s%)",
+	"verbose_no_ansi": " %message% (%elapsed:6s%)",
+}
+
+// the frames used by default
+var DefaultFrames = []string{"-", "\\", "|", "/"}
+
+// A StatusPrinter prints the final status of an indicator (e.g. *go_console.Styler)
+type StatusPrinter interface {
+	PrintSuccess(message string)
+	PrintError(message string)
+}
+
+// A ProgressIndicator displays a spinner with a message for a task of unknown length,
+// animated by a background goroutine until the indicator is stopped
+type ProgressIndicator struct {
+	output  output.OutputInterface
+	printer StatusPrinter
+
+	format    string
+	frames    []string
+	interval  time.Duration
+	message   string
+	startTime time.Time
+	frame     int
+
+	// guards the state shared with the ticker goroutine and every write on the output
+	mutex   sync.Mutex
+	started bool
+	drawn   bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// constructor, the final status is printed on the output when no status printer is set
+func NewProgressIndicator(out output.OutputInterface) *ProgressIndicator {
+	return &ProgressIndicator{
+		output:   out,
+		frames:   DefaultFrames,
+		interval: 100 * time.Millisecond,
+	}
+}
+
+// SetStatusPrinter prints the final status through the success and error styles of a styler
+func (i *ProgressIndicator) SetStatusPrinter(printer StatusPrinter) *ProgressIndicator {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.printer = printer
+	return i
+}
+
+// SetFormat sets a predefined format name ("normal", "verbose") or a custom format,
+// by default the format depends on the verbosity of the output
+func (i *ProgressIndicator) SetFormat(format string) *ProgressIndicator {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.format = format
+	return i
+}
+
+// SetFrames sets the characters displayed in turn by the spinner (at least two)
+func (i *ProgressIndicator) SetFrames(frames ...string) *ProgressIndicator {
+	if len(frames) < 2 {
+		panic(errors.New("a progress indicator needs at least two frames"))
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.frames = frames
+	return i
+}
+
+// SetInterval sets the duration between two frames
+func (i *ProgressIndicator) SetInterval(interval time.Duration) *ProgressIndicator {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.interval = interval
+	return i
+}
+
+// Start displays the indicator and animates it until Stop, Finish, Success or Fail
+func (i *ProgressIndicator) Start(message string) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if i.started {
+		panic(errors.New("progress indicator already started"))
+	}
+
+	i.started = true
+	i.message = message
+	i.startTime = time.Now()
+	i.frame = 0
+	i.display()
+
+	// nothing to animate when the spinner cannot be redrawn
+	if i.output.IsQuiet() || !i.output.IsDecorated() {
+		return
+	}
+
+	i.stop = make(chan struct{})
+	i.done = make(chan struct{})
+
+	go i.spin(i.stop, i.done)
+}
+
+// SetMessage replaces the message of the indicator
+func (i *ProgressIndicator) SetMessage(message string) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.message = message
+	i.display()
+}
+
+// Println prints a message above the indicator, which is redrawn below it
+// (the output must not be written directly while the indicator is running)
+func (i *ProgressIndicator) Println(message string) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if !i.started || i.output.IsQuiet() || !i.output.IsDecorated() {
+		i.output.Println(message)
+		return
+	}
+
+	i.write("")
+	i.output.Println(message)
+	i.display()
+}
+
+// Advance displays the next frame (called by the ticker goroutine)
+func (i *ProgressIndicator) Advance() {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if !i.started || !i.output.IsDecorated() {
+		return
+	}
+
+	i.frame++
+	i.display()
+}
+
+// Stop stops the animation and erases the indicator, safe to call several times
+func (i *ProgressIndicator) Stop() {
+	if !i.halt() {
+		return
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if i.output.IsDecorated() {
+		i.write("")
+	}
+}
+
+// Finish stops the animation and displays the message in place of the indicator
+func (i *ProgressIndicator) Finish(message string) {
+	if !i.halt() {
+		return
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.message = message
+	i.display()
+
+	_, inSection := i.output.(*output.ConsoleSectionOutput)
+
+	// ends the line of the indicator, sections and undecorated outputs already did
+	if !i.output.IsQuiet() && i.output.IsDecorated() && !inSection {
+		i.output.Println("")
+	}
+}
+
+// Success stops the animation and prints the message with the success style of the status printer
+func (i *ProgressIndicator) Success(message string) {
+	if i.printer == nil {
+		i.Finish(message)
+		return
+	}
+
+	i.Stop()
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.printer.PrintSuccess(message)
+}
+
+// Fail stops the animation and prints the message with the error style of the status printer
+func (i *ProgressIndicator) Fail(message string) {
+	if i.printer == nil {
+		i.Finish(message)
+		return
+	}
+
+	i.Stop()
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.printer.PrintError(message)
+}
+
+// halt stops the ticker goroutine and waits for it, returns false when the indicator was not started
+func (i *ProgressIndicator) halt() bool {
+	i.mutex.Lock()
+
+	if !i.started {
+		i.mutex.Unlock()
+		return false
+	}
+
+	i.started = false
+	stop, done := i.stop, i.done
+	i.stop, i.done = nil, nil
+
+	// the goroutine needs the lock to advance, it cannot be held while waiting
+	i.mutex.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+
+	return true
+}
+
+// spin advances the indicator at each tick until stopped
+func (i *ProgressIndicator) spin(stop <-chan struct{}, done chan<- struct{}) {
+	ticker := time.NewTicker(i.interval)
+
+	defer func() {
+		ticker.Stop()
+		close(done)
+	}()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			i.Advance()
+		}
+	}
+}
+
+// display draws the indicator, hidden when the output is quiet
+func (i *ProgressIndicator) display() {
+	if i.output.IsQuiet() {
+		return
+	}
+
+	line := placeholderRegex.ReplaceAllStringFunc(i.realFormat(), func(match string) string {
+		parts := placeholderRegex.FindStringSubmatch(match)
+		var text string
+
+		switch parts[1] {
+		case "indicator":
+			text = i.frames[i.frame%len(i.frames)]
+		case "message":
+			text = i.message
+		case "elapsed":
+			text = helper.FormatDuration(time.Since(i.startTime))
+		default:
+			return match
+		}
+
+		if parts[2] != "" {
+			return fmt.Sprintf("%"+parts[2], text)
+		}
+
+		return text
+	})
+
+	i.write(line)
+}
+
+// write replaces the indicator line, or writes a new line when the output is not decorated
+func (i *ProgressIndicator) write(line string) {
+	if !i.output.IsDecorated() {
+		i.output.Println(line)
+		return
+	}
+
+	// only the indicator line is replaced, the messages printed above it are kept
+	if section, ok := i.output.(*output.ConsoleSectionOutput); ok {
+		if i.drawn {
+			section.ClearLines(1)
+		}
+
+		if line != "" {
+			section.Println(line)
+		}
+
+		i.drawn = line != ""
+
+		return
+	}
+
+	// move back to the beginning of the line and erase it
+	i.output.Print("\x1b[1G\x1b[2K" + line)
+}
+
+// realFormat resolves the format, depending on the verbosity and the decoration of the output
+func (i *ProgressIndicator) realFormat() string {
+	name := i.format
+
+	if name == "" {
+		name = "normal"
+
+		if i.output.Verbosity() >= verbosity.Verbose {
+			name = "verbose"
+		}
+	}
+
+	if !i.output.IsDecorated() {
+		if format, ok := indicatorFormats[name+"_no_ansi"]; ok {
+			return format
+		}
+	}
+
+	if format, ok := indicatorFormats[name]; ok {
+		return format
+	}
+
+	return name
+}
@@ -9,19 +9,22 @@ import (
 	"github.com/DrSmithFr/go-console/question/answers"
 	"golang.org/x/term"
 	"io"
+	"os"
 	"strings"
 	"syscall"
 )
 
 type Helper struct {
-	in  io.Reader
-	out output.OutputInterface
+	in     io.Reader
+	reader *bufio.Reader
+	out    output.OutputInterface
 }
 
 func NewHelper(input io.Reader, output output.OutputInterface) *Helper {
 	return &Helper{
-		in:  input,
-		out: output,
+		in:     input,
+		reader: bufio.NewReader(input),
+		out:    output,
 	}
 }
 
@@ -66,14 +69,12 @@ func (h *Helper) doAsk(question QuestionBasicInterface) (string, error) {
 
 	var rawText string
 
-	if question.IsHidden() {
+	if question.IsHidden() && h.isTerminal() {
 		bytes, _ := term.ReadPassword(helper.Syscall(syscall.Stdin))
 		rawText = string(bytes)
 		h.out.Println("")
 	} else {
-		rawText, _ = bufio.
-			NewReader(h.in).
-			ReadString('\n')
+		rawText, _ = h.reader.ReadString('\n')
 	}
 
 	answer := strings.TrimSpace(rawText)
@@ -95,6 +96,11 @@ func (h *Helper) doAsk(question QuestionBasicInterface) (string, error) {
 	return answer, nil
 }
 
+// isTerminal returns true when reading from an interactive stdin
+func (h *Helper) isTerminal() bool {
+	return h.in == os.Stdin && term.IsTerminal(helper.Syscall(syscall.Stdin))
+}
+
 func (h *Helper) writePrompt(question QuestionBasicInterface) {
 	if choices, ok := question.(QuestionChoicesInterface); ok {
 		h.out.Println(fmt.Sprintf("<question>%s</question>", choices.GetQuestion()))
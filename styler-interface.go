@@ -1,6 +1,7 @@
 package go_console
 
 import (
+	"github.com/DrSmithFr/go-console/progress"
 	"github.com/DrSmithFr/go-console/verbosity"
 )
 
@@ -79,6 +80,9 @@ type StylerInterface interface {
 	// IsDebug Returns whether verbosity is debug (-vvv)
 	IsDebug() bool
 
+	// CreateProgressIndicator creates a spinner printing its final status with the success and error styles.
+	CreateProgressIndicator() *progress.ProgressIndicator
+
 	// TODO Formats a table.
 	// Table(headers []string, rows [][]string)
 
@@ -6,6 +6,7 @@ import (
 	"github.com/DrSmithFr/go-console/helper"
 	"github.com/DrSmithFr/go-console/input"
 	"github.com/DrSmithFr/go-console/output"
+	"github.com/DrSmithFr/go-console/progress"
 	"github.com/DrSmithFr/go-console/verbosity"
 	"io"
 	"strings"
@@ -131,23 +132,24 @@ func (g *Styler) PrintSuccesses(messages []string) {
 	g.blockList(messages, "OK", "fg=black;bg=green", " ", true, false)
 }
 
+// PrintError formats and print an error result bar (on the error output).
 func (g *Styler) PrintError(message string) {
 	g.PrintErrors([]string{message})
 }
 
-// PrintErrors formats and print an error result bar.
+// PrintErrors formats and print an error result bar (on the error output).
 func (g *Styler) PrintErrors(messages []string) {
-	g.blockList(messages, "ERROR", "fg=white;bg=red", " ", true, false)
+	g.ErrorStyle().blockList(messages, "ERROR", "fg=white;bg=red", " ", true, false)
 }
 
-// PrintWarning formats and print an warning result bar.
+// PrintWarning formats and print an warning result bar (on the error output).
 func (g *Styler) PrintWarning(message string) {
 	g.PrintWarnings([]string{message})
 }
 
-// PrintWarnings formats and print an warning result bar.
+// PrintWarnings formats and print an warning result bar (on the error output).
 func (g *Styler) PrintWarnings(messages []string) {
-	g.blockList(messages, "WARNING", "fg=white;bg=red", " ", true, false)
+	g.ErrorStyle().blockList(messages, "WARNING", "fg=white;bg=red", " ", true, false)
 }
 
 // PrintNote formats and print a note.
@@ -160,14 +162,42 @@ func (g *Styler) PrintNotes(messages []string) {
 	g.blockList(messages, "NOTE", "fg=yellow", " ! ", false, false)
 }
 
-// PrintCaution formats and print a caution.
+// PrintCaution formats and print a caution (on the error output).
 func (g *Styler) PrintCaution(message string) {
 	g.PrintCautions([]string{message})
 }
 
-// PrintCautions formats and print a caution.
+// PrintCautions formats and print a caution (on the error output).
 func (g *Styler) PrintCautions(messages []string) {
-	g.blockList(messages, "CAUTION", "fg=white;bg=red", " ! ", true, false)
+	g.ErrorStyle().blockList(messages, "CAUTION", "fg=white;bg=red", " ! ", true, false)
+}
+
+// ErrorStyle return a styler writing to the error output (stderr) of the output,
+// the styler itself when the output has no error output
+func (g *Styler) ErrorStyle() *Styler {
+	console, ok := g.output.(output.ConsoleOutputInterface)
+
+	if !ok || console.ErrorOutput() == g.output {
+		return g
+	}
+
+	errorOutput := console.ErrorOutput()
+
+	// clone the formatter to retrieve styles and avoid state change
+	format := *errorOutput.Formatter()
+
+	return &Styler{
+		input:          g.input,
+		output:         errorOutput,
+		bufferedOutput: *output.NewBufferedOutput(false, &format),
+		maxLineLength:  g.maxLineLength,
+	}
+}
+
+// CreateProgressIndicator return a spinner on the output,
+// printing its final status with the success and error styles
+func (g *Styler) CreateProgressIndicator() *progress.ProgressIndicator {
+	return progress.NewProgressIndicator(g.output).SetStatusPrinter(g)
 }
 
 //
@@ -0,0 +1,59 @@
+package tester
+
+import (
+	"context"
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/output"
+	"strings"
+)
+
+// NewCommandTester create a tester for the given command
+func NewCommandTester(command *go_console.Command) *CommandTester {
+	return &CommandTester{
+		command:   command,
+		inputs:    []string{},
+		decorated: false,
+	}
+}
+
+// CommandTester eases the testing of a go_console.Command, without exiting the process
+type CommandTester struct {
+	command   *go_console.Command
+	inputs    []string
+	decorated bool
+}
+
+// SetInputs set the answers given to the script questions, one per line (fluent)
+func (t *CommandTester) SetInputs(inputs []string) *CommandTester {
+	t.inputs = inputs
+	return t
+}
+
+// SetDecorated enable or disable output decoration (fluent)
+func (t *CommandTester) SetDecorated(decorated bool) *CommandTester {
+	t.decorated = decorated
+	return t
+}
+
+// Execute run the command with the given arguments (without the program name)
+func (t *CommandTester) Execute(args []string) *Result {
+	out := output.NewBufferedOutput(t.decorated, nil)
+
+	t.command.Output = out
+	t.command.SetStream(strings.NewReader(joinInputs(t.inputs)))
+
+	code, err := t.command.Execute(context.Background(), append([]string{"command"}, args...))
+
+	result := &Result{
+		ExitCode: code,
+		Error:    err,
+		Input:    t.command.Input,
+		output:   out.Fetch(),
+	}
+
+	if script := t.command.CurrentScript(); script != nil {
+		result.Input = script.Input
+	}
+
+	return result
+}
@@ -0,0 +1,121 @@
+package tester
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input"
+	"regexp"
+	"strings"
+)
+
+var ansiRegex = regexp.MustCompile("\033\\[[0-9;]*m")
+
+// Result of a script or command execution
+type Result struct {
+	ExitCode go_console.ExitCode
+	Error    error
+
+	// Input the parsed input of the executed script
+	Input input.InputInterface
+
+	output string
+}
+
+// Output returns the raw output (with decoration when enabled)
+func (r *Result) Output() string {
+	return r.output
+}
+
+// Display returns the output without decoration
+func (r *Result) Display() string {
+	return ansiRegex.ReplaceAllString(r.output, "")
+}
+
+// Lines returns the output without decoration split by line, trailing spaces removed
+func (r *Result) Lines() []string {
+	lines := strings.Split(r.Display(), "\n")
+
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	return lines
+}
+
+// Arguments returns the values of the arguments, as Input.Argument() resolves them
+// (command line, environment, configuration file or default value)
+func (r *Result) Arguments() map[string]string {
+	values := map[string]string{}
+
+	if r.Input == nil {
+		return values
+	}
+
+	for _, name := range r.Input.Definition().ArgumentsOrder() {
+		if !r.Input.Definition().Argument(name).IsList() {
+			values[name] = r.Input.Argument(name)
+		}
+	}
+
+	return values
+}
+
+// ArgumentLists returns the values of the list arguments, as Input.ArgumentList() resolves them
+func (r *Result) ArgumentLists() map[string][]string {
+	values := map[string][]string{}
+
+	if r.Input == nil {
+		return values
+	}
+
+	for _, name := range r.Input.Definition().ArgumentsOrder() {
+		if r.Input.Definition().Argument(name).IsList() {
+			values[name] = r.Input.ArgumentList(name)
+		}
+	}
+
+	return values
+}
+
+// Options returns the values of the options, as Input.Option() resolves them
+// (command line, environment, configuration file or default value)
+func (r *Result) Options() map[string]string {
+	values := map[string]string{}
+
+	if r.Input == nil {
+		return values
+	}
+
+	for _, name := range r.Input.Definition().OptionsOrder() {
+		if !r.Input.Definition().Option(name).IsList() {
+			values[name] = r.Input.Option(name)
+		}
+	}
+
+	return values
+}
+
+// OptionLists returns the values of the list options, as Input.OptionList() resolves them
+func (r *Result) OptionLists() map[string][]string {
+	values := map[string][]string{}
+
+	if r.Input == nil {
+		return values
+	}
+
+	for _, name := range r.Input.Definition().OptionsOrder() {
+		if r.Input.Definition().Option(name).IsList() {
+			values[name] = r.Input.OptionList(name)
+		}
+	}
+
+	return values
+}
+
+// joinInputs create the scripted stdin content from answers
+func joinInputs(inputs []string) string {
+	if len(inputs) == 0 {
+		return ""
+	}
+
+	return strings.Join(inputs, "\n") + "\n"
+}
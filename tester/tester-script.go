@@ -0,0 +1,56 @@
+package tester
+
+import (
+	"context"
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/output"
+	"strings"
+)
+
+// NewScriptTester create a tester for the given script
+func NewScriptTester(script *go_console.Script) *ScriptTester {
+	return &ScriptTester{
+		script:    script,
+		inputs:    []string{},
+		decorated: false,
+	}
+}
+
+// ScriptTester eases the testing of a go_console.Script, without exiting the process
+type ScriptTester struct {
+	script    *go_console.Script
+	inputs    []string
+	decorated bool
+}
+
+// SetInputs set the answers given to the script questions, one per line (fluent)
+func (t *ScriptTester) SetInputs(inputs []string) *ScriptTester {
+	t.inputs = inputs
+	return t
+}
+
+// SetDecorated enable or disable output decoration (fluent)
+func (t *ScriptTester) SetDecorated(decorated bool) *ScriptTester {
+	t.decorated = decorated
+	return t
+}
+
+// Execute run the script with the given arguments (without the program name)
+func (t *ScriptTester) Execute(args []string) *Result {
+	out := output.NewBufferedOutput(t.decorated, nil)
+	in := input.NewArgvInput(append([]string{"script"}, args...))
+
+	t.script.Input = in
+	t.script.Output = out
+	t.script.SetStream(strings.NewReader(joinInputs(t.inputs)))
+
+	code, err := t.script.Execute(context.Background())
+
+	return &Result{
+		ExitCode: code,
+		Error:    err,
+		Input:    t.script.Input,
+		output:   out.Fetch(),
+	}
+}
@@ -0,0 +1,79 @@
+package command
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/tester"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newAliasedCommand() *go_console.Command {
+	return &go_console.Command{
+		UseNamespace: true,
+		Scripts: []*go_console.Script{
+			{
+				Name:        "db:migrate",
+				Description: "Run migrations",
+				Aliases:     []string{"migrate"},
+				Runner:      printName,
+			},
+			{
+				Name:        "db:maintenance",
+				Description: "Internal maintenance",
+				Hidden:      true,
+				Runner:      printName,
+			},
+			{
+				Name:        "cache:clear",
+				Description: "Clear the cache",
+				Runner:      printName,
+			},
+		},
+	}
+}
+
+func TestAliasIsRunnable(t *testing.T) {
+	result := tester.
+		NewCommandTester(newAliasedCommand()).
+		Execute([]string{"migrate"})
+
+	assert.Nil(t, result.Error)
+	assert.Contains(t, result.Display(), "running db:migrate")
+}
+
+func TestAliasAbbreviation(t *testing.T) {
+	result := tester.
+		NewCommandTester(newAliasedCommand()).
+		Execute([]string{"mig"})
+
+	assert.Nil(t, result.Error)
+	assert.Contains(t, result.Display(), "running db:migrate")
+}
+
+func TestHiddenScriptIsRunnable(t *testing.T) {
+	result := tester.
+		NewCommandTester(newAliasedCommand()).
+		Execute([]string{"db:maintenance"})
+
+	assert.Nil(t, result.Error)
+	assert.Contains(t, result.Display(), "running db:maintenance")
+}
+
+func TestHiddenScriptIsNotAbbreviated(t *testing.T) {
+	result := tester.
+		NewCommandTester(newAliasedCommand()).
+		Execute([]string{"d:m"})
+
+	assert.Nil(t, result.Error)
+	assert.Contains(t, result.Display(), "running db:migrate")
+}
+
+func TestHiddenScriptIsNotListed(t *testing.T) {
+	result := tester.
+		NewCommandTester(newAliasedCommand()).
+		Execute([]string{"--help"})
+
+	assert.Contains(t, result.Display(), "db:migrate")
+	assert.Contains(t, result.Display(), "[migrate] Run migrations")
+	assert.NotContains(t, result.Display(), "db:maintenance")
+}
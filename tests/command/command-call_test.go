@@ -0,0 +1,158 @@
+package command
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/tester"
+	"github.com/DrSmithFr/go-console/verbosity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newCallCommand(deploy go_console.CommandRunner) *go_console.Command {
+	return &go_console.Command{
+		Scripts: []*go_console.Script{
+			{
+				Name:   "deploy",
+				Runner: deploy,
+			},
+			{
+				Name: "cache:clear",
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					cmd.PrintText("cache cleared")
+
+					if cmd.Output.IsVeryVerbose() {
+						cmd.PrintText("verbose cache")
+					}
+
+					return go_console.ExitSuccess
+				},
+			},
+			{
+				Name: "db:migrate",
+				Arguments: []go_console.Argument{
+					{Name: "version", Value: argument.Optional, DefaultValue: "latest"},
+				},
+				Options: []go_console.Option{
+					{Name: "force", Value: option.None},
+				},
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					if !cmd.Input.OptionBool("force") {
+						cmd.PrintError("use --force")
+						return go_console.ExitError
+					}
+
+					cmd.PrintText("migrated to " + cmd.Input.Argument("version"))
+					return go_console.ExitSuccess
+				},
+			},
+		},
+	}
+}
+
+func TestCallScript(t *testing.T) {
+	command := newCallCommand(func(cmd *go_console.Script) go_console.ExitCode {
+		if code, err := cmd.Call("cache:clear", nil); err != nil || code != go_console.ExitSuccess {
+			return code
+		}
+
+		code, _ := cmd.Call("db:migrate", []string{"42", "--force"})
+		return code
+	})
+
+	result := tester.NewCommandTester(command).Execute([]string{"deploy"})
+
+	assert.Nil(t, result.Error)
+	assert.Equal(t, go_console.ExitSuccess, result.ExitCode)
+	assert.Contains(t, result.Display(), "cache cleared")
+	assert.NotContains(t, result.Display(), "verbose cache")
+	assert.Contains(t, result.Display(), "migrated to 42")
+}
+
+func TestCallShareVerbosity(t *testing.T) {
+	var levels []verbosity.Level
+
+	command := newCallCommand(func(cmd *go_console.Script) go_console.ExitCode {
+		cmd.Call("cache:clear", nil)
+		levels = append(levels, cmd.Output.Verbosity())
+
+		cmd.Call("cache:clear", []string{"-q"})
+		levels = append(levels, cmd.Output.Verbosity())
+
+		return go_console.ExitSuccess
+	})
+
+	result := tester.NewCommandTester(command).Execute([]string{"deploy", "-vv"})
+
+	assert.Nil(t, result.Error)
+	assert.Contains(t, result.Display(), "verbose cache")
+	assert.Equal(t, []verbosity.Level{verbosity.VeryVerbose, verbosity.VeryVerbose}, levels)
+}
+
+func TestCallExitCode(t *testing.T) {
+	var codes []go_console.ExitCode
+	var errs []error
+
+	command := newCallCommand(func(cmd *go_console.Script) go_console.ExitCode {
+		for _, args := range [][]string{{}, {"--force"}, {"--unknown"}} {
+			code, err := cmd.Call("db:migrate", args)
+			codes = append(codes, code)
+			errs = append(errs, err)
+		}
+
+		code, err := cmd.CallInput("db:migrate", input.NewArrayInput(map[string]interface{}{"version": "7", "--force": true}))
+		codes = append(codes, code)
+		errs = append(errs, err)
+
+		return go_console.ExitSuccess
+	})
+
+	result := tester.NewCommandTester(command).Execute([]string{"deploy"})
+
+	assert.Nil(t, result.Error)
+	assert.Equal(t, []go_console.ExitCode{go_console.ExitError, go_console.ExitSuccess, go_console.ExitInvalid, go_console.ExitSuccess}, codes)
+	assert.Nil(t, errs[0])
+	assert.EqualError(t, errs[2], "the '--unknown' option does not exist")
+	assert.Contains(t, result.Display(), "migrated to latest")
+	assert.Contains(t, result.Display(), "migrated to 7")
+}
+
+func TestCallUnknownScript(t *testing.T) {
+	var errs []error
+
+	command := newCallCommand(func(cmd *go_console.Script) go_console.ExitCode {
+		_, err := cmd.Call("cache:warmup", nil)
+		errs = append(errs, err)
+
+		_, err = cmd.Call("deploy", nil)
+		errs = append(errs, err)
+
+		return go_console.ExitSuccess
+	})
+
+	tester.NewCommandTester(command).Execute([]string{"deploy"})
+
+	assert.EqualError(t, errs[0], "command 'cache:warmup' is not defined")
+	assert.EqualError(t, errs[1], "command 'deploy' is already running")
+}
+
+func TestFindScript(t *testing.T) {
+	command := newNestedCommand()
+	command.UseNamespace = true
+
+	assert.Equal(t, "up", command.Find("db migrate up").Name)
+	assert.Equal(t, "db", command.Find("db").Name)
+	assert.Nil(t, command.Find("db migrate down"))
+	assert.Nil(t, command.Find(""))
+
+	command = newCallCommand(func(cmd *go_console.Script) go_console.ExitCode {
+		return go_console.ExitSuccess
+	})
+	assert.Equal(t, "cache:clear", command.Find("cache:clear").Name)
+
+	tester.NewCommandTester(command).Execute([]string{"list"})
+	command.UseNamespace = true
+	assert.Equal(t, "db:migrate", command.Find("d:m").Name)
+}
@@ -0,0 +1,122 @@
+package command
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/completion"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/tester"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func newCompletionCommand() *go_console.Command {
+	return &go_console.Command{
+		UseNamespace: true,
+		Options: []go_console.Option{
+			{
+				Name:         "env",
+				Shortcut:     "e",
+				Value:        option.Required,
+				DefaultValue: "dev",
+				Completion:   completion.Values("dev", "prod", "test"),
+			},
+		},
+		Scripts: []*go_console.Script{
+			{
+				Name:    "cache:clear",
+				Aliases: []string{"cc"},
+				Options: []go_console.Option{
+					{Name: "no-warmup", Value: option.None},
+				},
+				Runner: printName,
+			},
+			{
+				Name: "cache:warmup",
+				Arguments: []go_console.Argument{
+					{
+						Name:  "pools",
+						Value: argument.List | argument.Optional,
+						Completion: func(value string, in completion.Input) []string {
+							// candidates depend on the input typed so far
+							return completion.Values(in.Option("env")+"-app", in.Option("env")+"-system")(value, in)
+						},
+					},
+				},
+				Runner: printName,
+			},
+			{Name: "secret:dump", Hidden: true, Runner: printName},
+			{
+				Name: "db",
+				Scripts: []*go_console.Script{
+					{Name: "migrate", Runner: printName},
+					{Name: "drop", Runner: printName},
+				},
+			},
+		},
+	}
+}
+
+func complete(words ...string) []string {
+	result := tester.
+		NewCommandTester(newCompletionCommand()).
+		Execute(append([]string{go_console.CompleteScriptName}, words...))
+
+	return strings.Split(strings.TrimSpace(result.Display()), "\n")
+}
+
+func TestCompleteScriptNames(t *testing.T) {
+	assert.Equal(t, []string{"cache:clear", "cache:warmup"}, complete("cache"))
+	assert.Equal(t, []string{"cache:clear", "cache:warmup", "cc", "completion", "db"}, complete(""))
+}
+
+func TestCompleteOptions(t *testing.T) {
+	assert.Equal(t, []string{"--no-warmup", "--no-interaction", "--no-ansi"}, complete("cache:clear", "--no"))
+	assert.Equal(t, []string{"--env"}, complete("cc", "--en"))
+	assert.Equal(t, []string{"-e"}, complete("-e"))
+}
+
+func TestCompleteNestedScripts(t *testing.T) {
+	assert.Equal(t, []string{"migrate", "drop"}, complete("db", ""))
+}
+
+func TestCompleteOptionValues(t *testing.T) {
+	assert.Equal(t, []string{"dev", "prod", "test"}, complete("--env", ""))
+	assert.Equal(t, []string{"prod"}, complete("cc", "-e", "p"))
+	assert.Equal(t, []string{"--env=test"}, complete("cc", "--env=t"))
+	assert.Equal(t, []string{""}, complete("cc", "--env=x"))
+}
+
+func TestCompleteArgumentValues(t *testing.T) {
+	assert.Equal(t, []string{"dev-app", "dev-system"}, complete("cache:warmup", ""))
+	assert.Equal(t, []string{"prod-system"}, complete("--env=prod", "cache:warmup", "prod-app", "prod-s"))
+	assert.Equal(t, []string{"test-app"}, complete("cache:warmup", "-e", "test", "test-a"))
+	assert.Equal(t, []string{"prod-app"}, complete("--env", "prod", "cache:warmup", "prod-a"))
+	assert.Equal(t, []string{"migrate"}, complete("-e", "prod", "db", "m"))
+	assert.Equal(t, []string{""}, complete("cache:clear", ""))
+}
+
+func TestCompletionScript(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		result := tester.
+			NewCommandTester(newCompletionCommand()).
+			Execute([]string{"completion", shell})
+
+		assert.Nil(t, result.Error)
+		assert.Contains(t, result.Display(), `"command" __complete`)
+	}
+
+	bash := tester.
+		NewCommandTester(newCompletionCommand()).
+		Execute([]string{"completion", "bash"})
+
+	assert.Contains(t, bash.Display(), `read -ra words <<< "$line"`)
+	assert.Contains(t, bash.Display(), "complete -o default -F _command_completion command")
+
+	unknown := tester.
+		NewCommandTester(newCompletionCommand()).
+		Execute([]string{"completion", "powershell"})
+
+	assert.Equal(t, go_console.ExitInvalid, unknown.ExitCode)
+}
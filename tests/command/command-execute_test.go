@@ -0,0 +1,113 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCommandExecuteTwice(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	cmd := &go_console.Command{
+		Output:  out,
+		Scripts: newNestedCommand().Scripts,
+	}
+
+	code, err := cmd.Execute(context.Background(), []string{"app", "db", "migrate", "up", "1"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Contains(t, out.Fetch(), "up 1 on sqlite://")
+
+	code, err = cmd.Execute(context.Background(), []string{"app", "db", "migrate", "up", "2", "--dsn=pgsql://"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Contains(t, out.Fetch(), "up 2 on pgsql://")
+}
+
+func TestCommandExecuteHandleRuntimeException(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	var cmd *go_console.Command
+
+	cmd = &go_console.Command{
+		Output: out,
+		Scripts: []*go_console.Script{
+			{
+				Name: "import",
+				Runner: func(script *go_console.Script) go_console.ExitCode {
+					defer cmd.HandleRuntimeException()
+					panic(errors.New("boom"))
+				},
+			},
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		code, err := cmd.Execute(context.Background(), []string{"app", "import"})
+
+		assert.Equal(t, go_console.ExitInvalid, code)
+		assert.EqualError(t, err, "boom")
+		assert.Contains(t, out.Fetch(), "boom")
+	}
+}
+
+func TestCommandExecuteHandleInvalidValue(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	var cmd *go_console.Command
+
+	cmd = &go_console.Command{
+		Output: out,
+		Scripts: []*go_console.Script{
+			{
+				Name:      "import",
+				Arguments: []go_console.Argument{{Name: "limit", Value: argument.Required}},
+				Runner: func(script *go_console.Script) go_console.ExitCode {
+					defer cmd.HandleRuntimeException()
+					script.Input.ArgumentInt("limit")
+					return go_console.ExitSuccess
+				},
+			},
+		},
+	}
+
+	code, err := cmd.Execute(context.Background(), []string{"app", "import", "ten"})
+
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.NotNil(t, err)
+
+	display := out.Fetch()
+	assert.Contains(t, display, "limit")
+	assert.Contains(t, display, "Usage:")
+}
+
+func TestNewCommandStylerBeforeExecute(t *testing.T) {
+	cmd := go_console.NewCommand()
+
+	// the output is ready as soon as the command is created
+	assert.NotPanics(t, func() {
+		cmd.ErrorStyle()
+		_, _ = cmd.Write([]byte{})
+	})
+
+	out := output.NewBufferedOutput(false, nil)
+	cmd.Output = out
+
+	cmd.AddScript(&go_console.Script{Name: "hello"}, func(script *go_console.Script) go_console.ExitCode {
+		script.PrintText("hello")
+		return go_console.ExitSuccess
+	})
+
+	code, err := cmd.Execute(context.Background(), []string{"app", "hello"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Contains(t, out.Fetch(), "hello")
+}
@@ -0,0 +1,131 @@
+package command
+
+import (
+	"context"
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func executeInput(cmd *go_console.Command, in input.InputInterface) (go_console.ExitCode, error, string) {
+	out := output.NewBufferedOutput(false, nil)
+
+	cmd.Input = in
+	cmd.Output = out
+
+	code, err := cmd.Execute(context.Background(), []string{"app"})
+
+	return code, err, out.Fetch()
+}
+
+func TestCommandStringInput(t *testing.T) {
+	code, err, display := executeInput(newPersistentCommand(), input.NewStringInput("--env prod deploy"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Contains(t, display, "deploy on prod")
+
+	code, err, display = executeInput(newPersistentCommand(), input.NewStringInput("deploy --env=prod"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Contains(t, display, "deploy on prod")
+}
+
+func TestCommandStringInputError(t *testing.T) {
+	code, err, display := executeInput(newPersistentCommand(), input.NewStringInput(`deploy "my app`))
+
+	assert.EqualError(t, err, `unterminated quote in 'deploy "my app'`)
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.Contains(t, display, "unterminated quote")
+}
+
+func TestCommandArrayInput(t *testing.T) {
+	code, err, display := executeInput(newPersistentCommand(), input.NewArrayInput(map[string]interface{}{
+		"command": "deploy",
+		"--env":   "prod",
+	}))
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Contains(t, display, "deploy on prod")
+
+	code, err, display = executeInput(newNestedCommand(), input.NewArrayInput(map[string]interface{}{
+		"command": "db migrate up",
+		"version": "42",
+		"--dsn":   "pgsql://",
+	}))
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Contains(t, display, "up 42 on pgsql://")
+
+	code, err, _ = executeInput(newNestedCommand(), input.NewArrayInput(map[string]interface{}{
+		"command": "db migrate down",
+	}))
+
+	assert.EqualError(t, err, "command 'db migrate down' is not defined")
+	assert.Equal(t, go_console.ExitInvalid, code)
+}
+
+// the "Array and string inputs" example of the README
+func TestReadmeArrayInputExample(t *testing.T) {
+	migrate := func(cmd *go_console.Script) go_console.ExitCode {
+		cmd.PrintText("migrate " + cmd.Input.Argument("version") + " by " + cmd.Input.Option("step"))
+
+		if cmd.Input.OptionBool("force") {
+			cmd.PrintText("forced")
+		}
+
+		return go_console.ExitSuccess
+	}
+
+	definition := func() *go_console.Script {
+		return &go_console.Script{
+			Name: "db:migrate",
+			Arguments: []go_console.Argument{
+				{Name: "version", Value: argument.Optional, DefaultValue: "latest"},
+			},
+			Options: []go_console.Option{
+				{Name: "force", Value: option.None},
+				{Name: "step", Value: option.Optional, DefaultValue: "1"},
+			},
+			Runner: migrate,
+		}
+	}
+
+	out := output.NewBufferedOutput(false, nil)
+
+	script := definition()
+	script.Output = out
+	script.Input = input.NewArrayInput(map[string]interface{}{
+		"version": "42",
+		"--force": true,
+		"--step":  3,
+	})
+
+	code, err := script.Execute(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Contains(t, out.Fetch(), "migrate 42 by 3\nforced")
+
+	cmd := &go_console.Command{
+		Output: out,
+		Input: input.NewArrayInput(map[string]interface{}{
+			"command": "db:migrate",
+			"--force": true,
+		}),
+		Scripts: []*go_console.Script{definition()},
+	}
+
+	code, err = cmd.Execute(context.Background(), []string{"app"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Contains(t, out.Fetch(), "migrate latest by 1\nforced")
+}
@@ -0,0 +1,105 @@
+package command
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/tester"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func printName(cmd *go_console.Script) go_console.ExitCode {
+	cmd.PrintText("running " + cmd.Name)
+	return go_console.ExitSuccess
+}
+
+func newNestedCommand() *go_console.Command {
+	return &go_console.Command{
+		Scripts: []*go_console.Script{
+			{
+				Name:        "db",
+				Description: "Database commands",
+				Options: []go_console.Option{
+					{Name: "dsn", Value: option.Required, DefaultValue: "sqlite://"},
+				},
+				Scripts: []*go_console.Script{
+					{
+						Name:        "migrate",
+						Description: "Migration commands",
+						Runner:      printName,
+						Scripts: []*go_console.Script{
+							{
+								Name:        "up",
+								Description: "Apply migrations",
+								Arguments: []go_console.Argument{
+									{Name: "version", Value: argument.Optional},
+								},
+								Runner: func(cmd *go_console.Script) go_console.ExitCode {
+									cmd.PrintText("up " + cmd.Input.Argument("version") + " on " + cmd.Input.Option("dsn"))
+									return go_console.ExitSuccess
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNestedScriptInheritOptions(t *testing.T) {
+	result := tester.
+		NewCommandTester(newNestedCommand()).
+		Execute([]string{"db", "migrate", "up", "42", "--dsn=pgsql://"})
+
+	assert.Nil(t, result.Error)
+	assert.Equal(t, go_console.ExitSuccess, result.ExitCode)
+	assert.Contains(t, result.Display(), "up 42 on pgsql://")
+}
+
+func TestNestedScriptIntermediateRunner(t *testing.T) {
+	result := tester.
+		NewCommandTester(newNestedCommand()).
+		Execute([]string{"db", "migrate"})
+
+	assert.Nil(t, result.Error)
+	assert.Contains(t, result.Display(), "running migrate")
+}
+
+func TestNestedScriptGroupOnly(t *testing.T) {
+	result := tester.
+		NewCommandTester(newNestedCommand()).
+		Execute([]string{"db"})
+
+	assert.NotNil(t, result.Error)
+	assert.Equal(t, go_console.ExitInvalid, result.ExitCode)
+	assert.Contains(t, result.Display(), "Available commands:")
+	assert.Contains(t, result.Display(), "migrate")
+}
+
+func TestNestedScriptHelp(t *testing.T) {
+	result := tester.
+		NewCommandTester(newNestedCommand()).
+		Execute([]string{"db", "migrate", "--help"})
+
+	assert.Equal(t, go_console.ExitSuccess, result.ExitCode)
+	assert.Contains(t, result.Display(), "command db migrate [command]")
+	assert.Contains(t, result.Display(), "--dsn")
+	assert.Contains(t, result.Display(), "Apply migrations")
+}
+
+func TestNestedScriptIntermediateOptions(t *testing.T) {
+	for _, args := range [][]string{
+		{"db", "--dsn=pgsql://", "migrate", "up", "42"},
+		{"db", "--dsn", "pgsql://", "migrate", "up", "42"},
+		{"db", "migrate", "--dsn", "pgsql://", "up", "42"},
+	} {
+		result := tester.
+			NewCommandTester(newNestedCommand()).
+			Execute(args)
+
+		assert.Nil(t, result.Error)
+		assert.Contains(t, result.Display(), "up 42 on pgsql://")
+	}
+}
@@ -0,0 +1,108 @@
+package command
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input/constraint"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/tester"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newPersistentCommand() *go_console.Command {
+	return &go_console.Command{
+		Options: []go_console.Option{
+			{Name: "env", Shortcut: "e", Value: option.Required, DefaultValue: "dev", Description: "The environment."},
+		},
+		Scripts: []*go_console.Script{
+			{
+				Name: "deploy",
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					cmd.PrintText("deploy on " + cmd.Input.Option("env"))
+					return go_console.ExitSuccess
+				},
+			},
+		},
+	}
+}
+
+func TestPersistentOptionAfterCommandName(t *testing.T) {
+	result := tester.
+		NewCommandTester(newPersistentCommand()).
+		Execute([]string{"deploy", "--env=prod"})
+
+	assert.Nil(t, result.Error)
+	assert.Equal(t, "prod", result.Options()["env"])
+	assert.Contains(t, result.Display(), "deploy on prod")
+}
+
+func TestPersistentOptionBeforeCommandName(t *testing.T) {
+	result := tester.
+		NewCommandTester(newPersistentCommand()).
+		Execute([]string{"-eprod", "deploy"})
+
+	assert.Nil(t, result.Error)
+	assert.Contains(t, result.Display(), "deploy on prod")
+}
+
+func TestPersistentOptionSeparateValueBeforeCommandName(t *testing.T) {
+	for _, args := range [][]string{
+		{"--env", "prod", "deploy"},
+		{"-e", "prod", "deploy"},
+		{"-q", "--env", "prod", "deploy", "-v"},
+	} {
+		result := tester.
+			NewCommandTester(newPersistentCommand()).
+			Execute(args)
+
+		assert.Nil(t, result.Error)
+		assert.Equal(t, "prod", result.Options()["env"])
+	}
+
+	result := tester.
+		NewCommandTester(newPersistentCommand()).
+		Execute([]string{"--env", "prod", "deploy"})
+
+	assert.Contains(t, result.Display(), "deploy on prod")
+}
+
+func TestPersistentOptionDefault(t *testing.T) {
+	result := tester.
+		NewCommandTester(newPersistentCommand()).
+		Execute([]string{"deploy"})
+
+	assert.Nil(t, result.Error)
+	assert.Contains(t, result.Display(), "deploy on dev")
+}
+
+func TestPersistentOptionInHelp(t *testing.T) {
+	commandHelp := tester.
+		NewCommandTester(newPersistentCommand()).
+		Execute([]string{"--help"})
+
+	assert.Contains(t, commandHelp.Display(), "--env")
+
+	scriptHelp := tester.
+		NewCommandTester(newPersistentCommand()).
+		Execute([]string{"deploy", "--help"})
+
+	assert.Contains(t, scriptHelp.Display(), "--env")
+	assert.Contains(t, scriptHelp.Display(), "The environment.")
+}
+
+func TestCommandHelpOptionRows(t *testing.T) {
+	cmd := newPersistentCommand()
+	cmd.Options = append(cmd.Options, go_console.Option{
+		Name:         "region",
+		Value:        option.Required,
+		DefaultValue: "eu",
+		Env:          "APP_REGION",
+		Constraint:   constraint.Enum("eu", "us"),
+	})
+
+	display := tester.NewCommandTester(cmd).Execute([]string{"--help"}).Display()
+
+	// same rows as the script help
+	assert.Contains(t, display, "--[no-]ansi")
+	assert.Contains(t, display, `--region          [eu|us] [default: "eu"] [env: APP_REGION]`)
+}
@@ -0,0 +1,36 @@
+package command
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/tester"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUnknownScriptSuggestion(t *testing.T) {
+	cmd := &go_console.Command{
+		Scripts: []*go_console.Script{
+			{Name: "cache:clear", Runner: printName},
+			{Name: "cache:warmup", Runner: printName},
+		},
+	}
+
+	result := tester.NewCommandTester(cmd).Execute([]string{"cache:cleer"})
+
+	assert.Equal(t, go_console.ExitInvalid, result.ExitCode)
+	assert.Contains(t, result.Display(), "Command 'cache:cleer' is not defined.")
+	assert.Contains(t, result.Display(), "Did you mean 'cache:clear'?")
+}
+
+func TestUnknownOptionSuggestion(t *testing.T) {
+	cmd := &go_console.Command{
+		Scripts: []*go_console.Script{
+			{Name: "cache:clear", Runner: printName},
+		},
+	}
+
+	result := tester.NewCommandTester(cmd).Execute([]string{"cache:clear", "--quite"})
+
+	assert.Equal(t, go_console.ExitInvalid, result.ExitCode)
+	assert.Contains(t, result.Display(), "Did you mean '--quiet'?")
+}
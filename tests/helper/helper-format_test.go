@@ -0,0 +1,28 @@
+package helper
+
+import (
+	"github.com/DrSmithFr/go-console/helper"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "< 1 sec", helper.FormatDuration(0))
+	assert.Equal(t, "< 1 sec", helper.FormatDuration(500*time.Millisecond))
+	assert.Equal(t, "1 sec", helper.FormatDuration(time.Second))
+	assert.Equal(t, "59 secs", helper.FormatDuration(59*time.Second))
+	assert.Equal(t, "1 min", helper.FormatDuration(90*time.Second))
+	assert.Equal(t, "5 mins", helper.FormatDuration(5*time.Minute+10*time.Second))
+	assert.Equal(t, "1 hr", helper.FormatDuration(time.Hour))
+	assert.Equal(t, "23 hrs", helper.FormatDuration(23*time.Hour))
+	assert.Equal(t, "1 day", helper.FormatDuration(helper.Day))
+	assert.Equal(t, "40 days", helper.FormatDuration(40*helper.Day))
+}
+
+func TestFormatMemory(t *testing.T) {
+	assert.Equal(t, "512 B", helper.FormatMemory(512))
+	assert.Equal(t, "2 KiB", helper.FormatMemory(2048))
+	assert.Equal(t, "12.5 MiB", helper.FormatMemory(12*1024*1024+512*1024))
+	assert.Equal(t, "1.0 GiB", helper.FormatMemory(1024*1024*1024))
+}
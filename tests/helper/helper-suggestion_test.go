@@ -0,0 +1,31 @@
+package helper
+
+import (
+	"github.com/DrSmithFr/go-console/helper"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, helper.EditDistance("foo", "foo"))
+	assert.Equal(t, 3, helper.EditDistance("", "foo"))
+	assert.Equal(t, 1, helper.EditDistance("foo", "fo"))
+	assert.Equal(t, 1, helper.EditDistance("foo", "boo"))
+	assert.Equal(t, 1, helper.EditDistance("quite", "quiet"))
+	assert.Equal(t, 3, helper.EditDistance("kitten", "sitting"))
+	assert.Equal(t, 1, helper.EditDistance("café", "cafe"))
+}
+
+func TestAlternatives(t *testing.T) {
+	candidates := []string{"cache:clear", "cache:warmup", "server:start"}
+
+	assert.Equal(t, []string{"cache:clear"}, helper.Alternatives("cache:cleer", candidates))
+	assert.Equal(t, []string{"cache:clear", "cache:warmup"}, helper.Alternatives("cache", candidates))
+	assert.Equal(t, []string{}, helper.Alternatives("deploy", candidates))
+}
+
+func TestDidYouMean(t *testing.T) {
+	assert.Equal(t, "", helper.DidYouMean([]string{}))
+	assert.Equal(t, "Did you mean 'foo'?", helper.DidYouMean([]string{"foo"}))
+	assert.Equal(t, "Did you mean one of these? 'foo', 'bar'", helper.DidYouMean([]string{"foo", "bar"}))
+}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"github.com/DrSmithFr/go-console/input/config"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func write(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func assertValues(t *testing.T, path string) {
+	values, err := config.Load(path)
+
+	assert.Nil(t, err)
+	assert.Equal(t, path, values.Path())
+
+	dsn, ok := values.Value("dsn")
+	assert.True(t, ok)
+	assert.Equal(t, "mysql://localhost", dsn)
+
+	hosts, _ := values.List("hosts")
+	assert.Equal(t, []string{"a.local", "b.local"}, hosts)
+
+	retries, _ := values.Value("retries")
+	assert.Equal(t, "3", retries)
+
+	debug, _ := values.Value("debug")
+	assert.Equal(t, "true", debug)
+
+	pool, _ := values.Value("db-pool")
+	assert.Equal(t, "10", pool)
+
+	_, ok = values.Value("missing")
+	assert.False(t, ok)
+}
+
+func TestLoadJson(t *testing.T) {
+	assertValues(t, write(t, "app.json", `{
+  "dsn": "mysql://localhost",
+  "hosts": ["a.local", "b.local"],
+  "retries": 3,
+  "debug": true,
+  "db": {"pool": 10}
+}`))
+}
+
+func TestLoadYaml(t *testing.T) {
+	assertValues(t, write(t, "app.yaml", `
+dsn: mysql://localhost
+hosts:
+  - a.local
+  - b.local
+retries: 3
+debug: true
+db:
+  pool: 10
+`))
+}
+
+func TestLoadToml(t *testing.T) {
+	assertValues(t, write(t, "app.toml", `
+# application settings
+dsn = "mysql://localhost" # inline comment
+hosts = [
+  "a.local",
+  'b.local',
+]
+retries = 3
+debug = true
+
+[db]
+pool = 10
+`))
+}
+
+func TestLoadTomlSyntax(t *testing.T) {
+	values, err := config.Load(write(t, "app.toml", `
+db = {dsn = "x", pool = 0x0A}
+text = """multi
+line"""
+octal = 0o10
+decimal = 10
+since = 1979-05-27T07:32:00Z
+day = 1979-05-27
+at = 07:32:00
+local = 1979-05-27T07:32:00
+`))
+
+	assert.Nil(t, err)
+
+	for name, expected := range map[string]string{
+		"db-dsn":  "x",
+		"db-pool": "10",
+		"text":    "multi\nline",
+		"octal":   "8",
+		"decimal": "10",
+		"since":   "1979-05-27T07:32:00Z",
+		"day":     "1979-05-27",
+		"at":      "07:32:00",
+		"local":   "1979-05-27T07:32:00",
+	} {
+		value, _ := values.Value(name)
+		assert.Equal(t, expected, value, name)
+	}
+}
+
+func TestLoadTomlInvalidSyntax(t *testing.T) {
+	for _, content := range []string{
+		"retries = 010",
+		"retries = 3\nname = bare",
+		"[[servers]]\nname = \"a\"",
+	} {
+		_, err := config.Load(write(t, "app.toml", content))
+
+		assert.NotNil(t, err, content)
+	}
+}
+
+func TestScalarKinds(t *testing.T) {
+	since := time.Date(1979, 5, 27, 7, 32, 0, 0, time.FixedZone("", -7*3600))
+
+	for expected, value := range map[string]interface{}{
+		"-8":                        int8(-8),
+		"-16":                       int16(-16),
+		"-32":                       int32(-32),
+		"-64":                       int64(-64),
+		"7":                         uint(7),
+		"8":                         uint8(8),
+		"16":                        uint16(16),
+		"32":                        uint32(32),
+		"18446744073709551615":      uint64(18446744073709551615),
+		"1.5":                       float32(1.5),
+		"1979-05-27T07:32:00-07:00": since,
+	} {
+		values, err := config.FromMap(map[string]interface{}{"value": value})
+
+		assert.Nil(t, err)
+
+		str, _ := values.Value("value")
+		assert.Equal(t, expected, str)
+	}
+
+	_, err := config.FromMap(map[string]interface{}{"value": struct{}{}})
+	assert.NotNil(t, err)
+}
+
+func TestLoadYamlTimestampAndUnsigned(t *testing.T) {
+	values, err := config.Load(write(t, "app.yaml", "since: 1979-05-27T07:32:00Z\nmax: 18446744073709551615\n"))
+
+	assert.Nil(t, err)
+
+	since, _ := values.Value("since")
+	assert.Equal(t, "1979-05-27T07:32:00Z", since)
+
+	max, _ := values.Value("max")
+	assert.Equal(t, "18446744073709551615", max)
+}
+
+func TestLoadDotenv(t *testing.T) {
+	assertValues(t, write(t, ".env", `
+# application settings
+DSN="mysql://localhost"
+export HOSTS=a.local,b.local
+RETRIES=3 # inline comment
+DEBUG='true'
+DB_POOL=10
+`))
+}
+
+func TestLoadErrors(t *testing.T) {
+	_, err := config.Load(write(t, "app.ini", "dsn=foo"))
+	assert.NotNil(t, err)
+
+	_, err = config.Load(write(t, "app.toml", "dsn"))
+	assert.NotNil(t, err)
+
+	_, err = config.Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.NotNil(t, err)
+}
+
+func TestFind(t *testing.T) {
+	path := write(t, "app.json", "{}")
+
+	assert.Equal(t, path, config.Find("missing.json", path))
+	assert.Equal(t, "", config.Find("missing.json"))
+}
@@ -0,0 +1,62 @@
+package constraint
+
+import (
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/constraint"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+func assertExpects(t *testing.T, c *constraint.Constraint, value string, expected string) {
+	err := c.Validate(value)
+
+	if expected == "" {
+		assert.Nil(t, err, value)
+		return
+	}
+
+	assert.EqualError(t, err, expected, value)
+}
+
+func TestValidate(t *testing.T) {
+	assertExpects(t, constraint.Int(), "42", "")
+	assertExpects(t, constraint.Int(), "4.2", "an integer")
+	assertExpects(t, constraint.Int().Range(1, 10), "11", "a value between 1 and 10")
+	assertExpects(t, constraint.Float().Min(0), "-0.5", "a value >= 0")
+	assertExpects(t, constraint.Float().Max(1), "0.5", "")
+	assertExpects(t, constraint.Bool(), "yes", "")
+	assertExpects(t, constraint.Bool(), "maybe", "a boolean")
+	assertExpects(t, constraint.Duration(), "1h30m", "")
+	assertExpects(t, constraint.Duration(), "soon", "a duration")
+	assertExpects(t, constraint.Enum("debug", "info", "warn"), "info", "")
+	assertExpects(t, constraint.Enum("debug", "info", "warn"), "trace", "one of debug, info, warn")
+	assertExpects(t, constraint.Url(), "https://example.com/path", "")
+	assertExpects(t, constraint.Url(), "example.com", "a URL")
+	assertExpects(t, constraint.String().Pattern("^[a-z]+$"), "Foo", "a value matching ^[a-z]+$")
+	assertExpects(t, constraint.Path(), "missing.txt", "")
+	assertExpects(t, constraint.Path().MustExist(), "missing.txt", "an existing path")
+	assertExpects(t, constraint.Path().MustExist(), os.TempDir(), "")
+}
+
+func TestDescribe(t *testing.T) {
+	assert.Equal(t, "int, between 1 and 65535", constraint.Int().Range(1, 65535).Describe())
+	assert.Equal(t, "debug|info|warn", constraint.Enum("debug", "info", "warn").Describe())
+	assert.Equal(t, "path, must exist", constraint.Path().MustExist().Describe())
+	assert.Equal(t, "debug|info|warn", constraint.Enum("debug", "info", "warn").Placeholder())
+	assert.Equal(t, "duration", constraint.Duration().Placeholder())
+}
+
+func TestInvalidConstraint(t *testing.T) {
+	assert.Panics(t, func() { constraint.New("date") })
+	assert.Panics(t, func() { constraint.Int().MustExist() })
+
+	// an enum without choices would accept any value
+	assert.Panics(t, func() { constraint.Enum() })
+	assert.Panics(t, func() { argument.New("env", argument.Required).SetConstraint(constraint.New(constraint.TypeEnum)) })
+	assert.Panics(t, func() { option.New("env", option.Required).SetConstraint(constraint.New(constraint.TypeEnum)) })
+	assert.NotPanics(t, func() {
+		option.New("env", option.Required).SetConstraint(constraint.New(constraint.TypeEnum).Choices("dev"))
+	})
+}
@@ -340,3 +340,85 @@ func provideInvalidInput() []*test_helper.ParserPattern {
 			SetMessage("The '-fЩ' option does not exist."),
 	}
 }
+
+func TestUnknownOptionSuggestion(t *testing.T) {
+	in := input.NewArgvInput([]string{"cli.php", "--verbos"})
+
+	assert.PanicsWithError(
+		t,
+		"the '--verbos' option does not exist. Did you mean '--verbose'?",
+		func() {
+			in.Bind(
+				*definition.New().
+					AddOption(*option.New("verbose", option.None)).
+					AddOption(*option.New("version", option.None)),
+			)
+		},
+	)
+}
+
+func TestHasParameterOption(t *testing.T) {
+	in := input.NewArgvInput([]string{"cli.php", "-f", "foo"})
+	assert.True(t, in.HasParameterOption([]string{"-f"}, false))
+
+	in = input.NewArgvInput([]string{"cli.php", "--foo", "foo"})
+	assert.True(t, in.HasParameterOption([]string{"--foo"}, false))
+
+	in = input.NewArgvInput([]string{"cli.php", "foo"})
+	assert.False(t, in.HasParameterOption([]string{"--foo"}, false))
+
+	in = input.NewArgvInput([]string{"cli.php", "--foo=bar"})
+	assert.True(t, in.HasParameterOption([]string{"--foo"}, false))
+	assert.False(t, in.HasParameterOption([]string{"--fo"}, false))
+
+	in = input.NewArgvInput([]string{"cli.php", "-vfx"})
+	assert.True(t, in.HasParameterOption([]string{"-f"}, false))
+	assert.True(t, in.HasParameterOption([]string{"-x"}, false))
+	assert.False(t, in.HasParameterOption([]string{"-e"}, false))
+
+	in = input.NewArgvInput([]string{"cli.php", "--", "--foo"})
+	assert.True(t, in.HasParameterOption([]string{"--foo"}, false))
+	assert.False(t, in.HasParameterOption([]string{"--foo"}, true))
+}
+
+func TestHasParameterOptionCombinedWithDefinition(t *testing.T) {
+	in := input.NewArgvInput([]string{"cli.php", "-evx"})
+
+	in.Bind(
+		*definition.New().
+			AddOption(*option.New("env", option.Required).SetShortcut("e")).
+			AddOption(*option.New("verbose", option.None).SetShortcut("v")),
+	)
+
+	// "vx" is the value of -e
+	assert.True(t, in.HasParameterOption([]string{"-e"}, false))
+	assert.False(t, in.HasParameterOption([]string{"-v"}, false))
+	assert.Equal(t, "vx", in.ParameterOption([]string{"-e"}, "", false))
+}
+
+func TestParameterOption(t *testing.T) {
+	cases := []struct {
+		argv     []string
+		values   []string
+		expected string
+	}{
+		{[]string{"cli.php", "-e", "prod"}, []string{"-e"}, "prod"},
+		{[]string{"cli.php", "-eprod"}, []string{"-e"}, "prod"},
+		{[]string{"cli.php", "-vvvvprod"}, []string{"-e", "-vvvv"}, "prod"},
+		{[]string{"cli.php", "-vep"}, []string{"-e"}, "p"},
+		{[]string{"cli.php", "-ve", "prod"}, []string{"-e"}, "prod"},
+		{[]string{"cli.php", "--env", "prod"}, []string{"--env"}, "prod"},
+		{[]string{"cli.php", "--env=prod"}, []string{"--env"}, "prod"},
+		{[]string{"cli.php", "--profile", "--env=prod"}, []string{"--env", "--profile"}, ""},
+		{[]string{"cli.php", "foo"}, []string{"--env"}, "default"},
+		{[]string{"cli.php", "--", "--env=prod"}, []string{"--env"}, "prod"},
+	}
+
+	for _, c := range cases {
+		in := input.NewArgvInput(c.argv)
+		assert.Equal(t, c.expected, in.ParameterOption(c.values, "default", false), c.argv)
+	}
+
+	in := input.NewArgvInput([]string{"cli.php", "--", "--env=prod"})
+	assert.Equal(t, "default", in.ParameterOption([]string{"--env"}, "default", true))
+}
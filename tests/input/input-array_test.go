@@ -0,0 +1,86 @@
+package input
+
+import (
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func arrayDefinition() definition.InputDefinition {
+	return *definition.New().
+		AddArgument(*argument.New("command", argument.Required)).
+		AddArgument(*argument.New("files", argument.Optional|argument.List)).
+		AddOption(*option.New("force", option.None).SetShortcut("f")).
+		AddOption(*option.New("cache", option.Negatable).SetDefault(option.Defined)).
+		AddOption(*option.New("step", option.Optional)).
+		AddOption(*option.New("tags", option.Optional|option.List)).
+		AddOption(*option.New("verbose", option.Count).SetShortcut("v"))
+}
+
+func TestArrayInput(t *testing.T) {
+	var in input.InputInterface = input.NewArrayInput(map[string]interface{}{
+		"command": "db:migrate",
+		"files":   []string{"a.sql", "b.sql"},
+		"-f":      true,
+		"--cache": false,
+		"--step":  3,
+		"--tags":  []interface{}{"x", 1},
+		"-v":      2,
+	})
+
+	in.Bind(arrayDefinition())
+	in.Validate()
+
+	assert.Equal(t, "db:migrate", in.Argument("command"))
+	assert.Equal(t, []string{"a.sql", "b.sql"}, in.ArgumentList("files"))
+	assert.True(t, in.OptionBool("force"))
+	assert.False(t, in.OptionBool("cache"))
+	assert.Equal(t, 3, in.OptionInt("step"))
+	assert.Equal(t, []string{"x", "1"}, in.OptionList("tags"))
+	assert.Equal(t, 2, in.OptionCount("verbose"))
+	assert.Equal(t, input.SourceCli, in.OptionSource("step"))
+}
+
+func TestArrayInputDefaults(t *testing.T) {
+	in := input.NewArrayInput(map[string]interface{}{"command": "list", "--force": false})
+	in.Bind(arrayDefinition())
+
+	assert.False(t, in.OptionBool("force"))
+	assert.True(t, in.OptionBool("cache"))
+	assert.Equal(t, []string{}, in.ArgumentList("files"))
+}
+
+func TestArrayInputParameters(t *testing.T) {
+	in := input.NewArrayInput(map[string]interface{}{"command": "list", "--env": "prod", "--force": true})
+
+	assert.Equal(t, "list", in.FirstArgument())
+	assert.True(t, in.HasParameterOption([]string{"--env", "-e"}, false))
+	assert.False(t, in.HasParameterOption([]string{"--profile"}, false))
+	assert.Equal(t, "prod", in.ParameterOption([]string{"--env"}, "dev", false))
+	assert.Equal(t, "dev", in.ParameterOption([]string{"--profile"}, "dev", false))
+}
+
+func TestInvalidArrayInput(t *testing.T) {
+	cases := []struct {
+		parameters map[string]interface{}
+		message    string
+	}{
+		{map[string]interface{}{"name": "foo"}, "the 'name' argument does not exist"},
+		{map[string]interface{}{"command": []string{"a", "b"}}, "the 'command' argument does not accept a list"},
+		{map[string]interface{}{"--foo": true}, "the '--foo' option does not exist"},
+		{map[string]interface{}{"-x": true}, "the '-x' option does not exist"},
+		{map[string]interface{}{"--force": "yes"}, "the '--force' option does not accept a value"},
+	}
+
+	for _, c := range cases {
+		in := input.NewArrayInput(c.parameters)
+		assert.PanicsWithError(t, c.message, func() { in.Bind(arrayDefinition()) })
+	}
+
+	in := input.NewArrayInput(nil)
+	in.Bind(arrayDefinition())
+	assert.PanicsWithError(t, "Argument 'command' is required", func() { in.Validate() })
+}
@@ -0,0 +1,56 @@
+package input
+
+import (
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func countInput(argv ...string) *input.ArgvInput {
+	in := input.NewArgvInput(append([]string{"cli.php"}, argv...))
+
+	in.Bind(
+		*definition.New().
+			AddOption(*option.New("verbose", option.Count).SetShortcut("v")).
+			AddOption(*option.New("quiet", option.None).SetShortcut("q")),
+	)
+
+	return in
+}
+
+func TestCountOption(t *testing.T) {
+	assert.Equal(t, 0, countInput().OptionCount("verbose"))
+	assert.Equal(t, 1, countInput("-v").OptionCount("verbose"))
+	assert.Equal(t, 3, countInput("-vvv").OptionCount("verbose"))
+	assert.Equal(t, 2, countInput("-v", "-v").OptionCount("verbose"))
+	assert.Equal(t, 2, countInput("--verbose", "--verbose").OptionCount("verbose"))
+	assert.Equal(t, 3, countInput("-vqv", "--verbose").OptionCount("verbose"))
+	assert.Equal(t, 2, countInput("--verbose=2").OptionCount("verbose"))
+	assert.True(t, countInput("-v").OptionBool("verbose"))
+}
+
+func TestCountOptionEnv(t *testing.T) {
+	in := input.NewArgvInput([]string{"cli.php"})
+	in.Bind(*definition.New().AddOption(*option.New("verbose", option.Count).SetEnv("APP_VERBOSITY")))
+
+	t.Setenv("APP_VERBOSITY", "2")
+	assert.Equal(t, 2, in.OptionCount("verbose"))
+}
+
+func TestCountOptionErrors(t *testing.T) {
+	assert.PanicsWithError(t, "the '--verbose' option expects a number of occurrences, 'high' given", func() {
+		countInput("--verbose=high")
+	})
+
+	assert.Panics(t, func() {
+		option.New("verbose", option.Count|option.Optional)
+	})
+
+	assert.Panics(t, func() {
+		countInput().OptionCount("quiet")
+	})
+
+	assert.Equal(t, "[-v|--verbose...] [-q|--quiet]", countInput().Definition().Synopsis(false))
+}
@@ -0,0 +1,55 @@
+package input
+
+import (
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func envDefinition() *definition.InputDefinition {
+	return definition.New().
+		AddArgument(*argument.New("dsn", argument.Optional).SetEnv("APP_DB_DSN").SetDefault("sqlite")).
+		AddOption(*option.New("env", option.Required).SetEnv("APP_ENV").SetDefault("dev")).
+		AddOption(*option.New("hosts", option.Required|option.List).SetEnv("APP_HOSTS")).
+		AddOption(*option.New("debug", option.None).SetEnv("APP_DEBUG"))
+}
+
+func TestEnvFallback(t *testing.T) {
+	t.Setenv("APP_DB_DSN", "mysql://localhost")
+	t.Setenv("APP_ENV", "prod")
+	t.Setenv("APP_HOSTS", "a.local, b.local")
+	t.Setenv("APP_DEBUG", "true")
+
+	in := input.NewArgvInput([]string{"cli.php"})
+	in.Bind(*envDefinition())
+
+	assert.Equal(t, "mysql://localhost", in.Argument("dsn"))
+	assert.Equal(t, "prod", in.Option("env"))
+	assert.Equal(t, []string{"a.local", "b.local"}, in.OptionList("hosts"))
+	assert.Equal(t, option.Defined, in.Option("debug"))
+}
+
+func TestEnvResolutionOrder(t *testing.T) {
+	t.Setenv("APP_ENV", "prod")
+	t.Setenv("APP_DEBUG", "0")
+
+	in := input.NewArgvInput([]string{"cli.php", "--env=test", "pgsql://localhost"})
+	in.Bind(*envDefinition())
+
+	// command line first, then environment, then default
+	assert.Equal(t, "pgsql://localhost", in.Argument("dsn"))
+	assert.Equal(t, "test", in.Option("env"))
+	assert.Equal(t, option.Undefined, in.Option("debug"))
+	assert.Equal(t, []string{}, in.OptionList("hosts"))
+}
+
+func TestEnvDefault(t *testing.T) {
+	in := input.NewArgvInput([]string{"cli.php"})
+	in.Bind(*envDefinition())
+
+	assert.Equal(t, "sqlite", in.Argument("dsn"))
+	assert.Equal(t, "dev", in.Option("env"))
+}
@@ -0,0 +1,65 @@
+package input
+
+import (
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func negatableInput(argv ...string) *input.ArgvInput {
+	in := input.NewArgvInput(append([]string{"cli.php"}, argv...))
+
+	in.Bind(
+		*definition.New().
+			AddOption(*option.New("cache", option.Negatable).SetDefault(option.Defined)).
+			AddOption(*option.New("color", option.Negatable).SetShortcut("c")),
+	)
+
+	return in
+}
+
+func TestNegatableOption(t *testing.T) {
+	in := negatableInput("--no-cache", "-c")
+
+	assert.Equal(t, option.Undefined, in.Option("cache"))
+	assert.False(t, in.OptionBool("cache"))
+	assert.True(t, in.OptionBool("color"))
+	assert.False(t, *in.OptionNegatable("cache"))
+	assert.True(t, *in.OptionNegatable("color"))
+
+	in = negatableInput()
+
+	assert.True(t, in.OptionBool("cache"))
+	assert.False(t, in.OptionBool("color"))
+	assert.Nil(t, in.OptionNegatable("cache"))
+	assert.Nil(t, in.OptionNegatable("color"))
+
+	in = negatableInput("--cache", "--no-color")
+
+	assert.True(t, *in.OptionNegatable("cache"))
+	assert.False(t, *in.OptionNegatable("color"))
+}
+
+func TestNegatableOptionErrors(t *testing.T) {
+	assert.PanicsWithError(t, "the '--no-cache' option does not accept a value", func() {
+		negatableInput("--no-cache=1")
+	})
+
+	assert.Panics(t, func() {
+		option.New("cache", option.Negatable|option.Required)
+	})
+
+	assert.Panics(t, func() {
+		option.New("cache", option.Negatable).SetDefault("yes")
+	})
+
+	assert.Panics(t, func() {
+		definition.New().
+			AddOption(*option.New("cache", option.Negatable)).
+			AddOption(*option.New("no-cache", option.None))
+	})
+
+	assert.Equal(t, "[--[no-]cache] [-c|--[no-]color]", negatableInput().Definition().Synopsis(false))
+}
@@ -0,0 +1,117 @@
+package input
+
+import (
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func groupInput(group *definition.OptionGroup, argv ...string) *input.ArgvInput {
+	in := input.NewArgvInput(append([]string{"cli.php"}, argv...))
+
+	in.Bind(
+		*definition.New().
+			AddOption(*option.New("json", option.Negatable)).
+			AddOption(*option.New("table", option.None)).
+			AddOption(*option.New("user", option.Optional)).
+			AddOption(*option.New("password", option.Optional)).
+			AddOptionGroup(group),
+	)
+
+	return in
+}
+
+func TestExclusiveGroup(t *testing.T) {
+	group := definition.Exclusive("json", "table")
+
+	assert.NotPanics(t, func() { groupInput(group).ValidateArgv() })
+	assert.NotPanics(t, func() { groupInput(group, "--json").ValidateArgv() })
+	assert.PanicsWithError(t, "the options '--json' and '--table' cannot be used together", func() {
+		groupInput(group, "--json", "--table").ValidateArgv()
+	})
+
+	// a negated flag is not given
+	assert.NotPanics(t, func() { groupInput(group, "--no-json", "--table").ValidateArgv() })
+}
+
+func TestAllOrNoneGroup(t *testing.T) {
+	group := definition.AllOrNone("user", "password")
+
+	assert.NotPanics(t, func() { groupInput(group).ValidateArgv() })
+	assert.NotPanics(t, func() { groupInput(group, "--user=bob", "--password=secret").ValidateArgv() })
+	assert.PanicsWithError(t, "the options '--user' and '--password' must be used together, missing '--user'", func() {
+		groupInput(group, "--password=secret").ValidateArgv()
+	})
+}
+
+func TestAtLeastOneGroup(t *testing.T) {
+	group := definition.AtLeastOne("json", "table")
+
+	assert.NotPanics(t, func() { groupInput(group, "--table").ValidateArgv() })
+	assert.PanicsWithError(t, "at least one of the options '--json' and '--table' is required", func() {
+		groupInput(group).ValidateArgv()
+	})
+}
+
+func TestRequiresGroup(t *testing.T) {
+	group := definition.Requires("user", "password")
+
+	assert.NotPanics(t, func() { groupInput(group).ValidateArgv() })
+	assert.NotPanics(t, func() { groupInput(group, "--password=secret").ValidateArgv() })
+	assert.PanicsWithError(t, "the '--user' option requires '--password'", func() {
+		groupInput(group, "--user=bob").ValidateArgv()
+	})
+}
+
+func TestGroupFromEnv(t *testing.T) {
+	t.Setenv("APP_PASSWORD", "secret")
+
+	in := input.NewArgvInput([]string{"cli.php", "--user=bob"})
+	in.Bind(
+		*definition.New().
+			AddOption(*option.New("user", option.Optional)).
+			AddOption(*option.New("password", option.Optional).SetEnv("APP_PASSWORD")).
+			AddOptionGroup(definition.Requires("user", "password")),
+	)
+
+	assert.NotPanics(t, func() { in.ValidateArgv() })
+}
+
+func TestGroupNegatedFlags(t *testing.T) {
+	t.Setenv("APP_TABLE", "false")
+
+	flagInput := func(group *definition.OptionGroup, argv ...string) *input.ArgvInput {
+		in := input.NewArgvInput(append([]string{"cli.php"}, argv...))
+		in.Bind(
+			*definition.New().
+				AddOption(*option.New("json", option.Negatable)).
+				AddOption(*option.New("table", option.Negatable).SetEnv("APP_TABLE")).
+				AddOptionGroup(group),
+		)
+
+		return in
+	}
+
+	// --no-json and APP_TABLE=false do not count as given
+	assert.NotPanics(t, func() { flagInput(definition.Exclusive("json", "table"), "--json").ValidateArgv() })
+	assert.NotPanics(t, func() { flagInput(definition.AllOrNone("json", "table"), "--no-json").ValidateArgv() })
+	assert.NotPanics(t, func() { flagInput(definition.Requires("json", "table"), "--no-json").ValidateArgv() })
+	assert.PanicsWithError(t, "the '--json' option requires '--table'", func() {
+		flagInput(definition.Requires("json", "table"), "--json").ValidateArgv()
+	})
+}
+
+func TestInvalidGroup(t *testing.T) {
+	assert.PanicsWithError(t, "a 'exclusive' option group needs at least two options", func() {
+		definition.Exclusive("json")
+	})
+	assert.PanicsWithError(t, "option group relation 'any' is not valid", func() {
+		definition.NewOptionGroup("any", "json", "table")
+	})
+	assert.PanicsWithError(t, "the 'csv' option of a group does not exist", func() {
+		groupInput(definition.Exclusive("json", "csv")).ValidateArgv()
+	})
+	assert.Equal(t, "--user requires --password", definition.Requires("user", "password").Describe())
+}
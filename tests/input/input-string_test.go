@@ -0,0 +1,76 @@
+package input
+
+import (
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStringInputTokens(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected []string
+	}{
+		{"", []string{}},
+		{"  foo   bar ", []string{"foo", "bar"}},
+		{`"quoted value" 'single quoted'`, []string{"quoted value", "single quoted"}},
+		{`--name="John Doe" --path='a b'`, []string{"--name=John Doe", "--path=a b"}},
+		{`'it\'s`, []string{`it\s`}},
+		{`"unterminated`, nil},
+		{`a\ b "say \"hi\"" "c:\temp"`, []string{"a b", `say "hi"`, `c:\temp`}},
+		{`'' ""`, []string{"", ""}},
+		{"multi\nline\ttab", []string{"multi", "line", "tab"}},
+	}
+
+	for _, c := range cases {
+		if c.expected == nil {
+			assert.Panics(t, func() { input.NewStringInput(c.input).Bind(*definition.New()) }, c.input)
+			continue
+		}
+
+		in := input.NewStringInput(c.input)
+		in.Bind(
+			*definition.New().
+				AddArgument(*argument.New("tokens", argument.Optional|argument.List)).
+				AddOption(*option.New("name", option.Optional)).
+				AddOption(*option.New("path", option.Optional)),
+		)
+
+		tokens := append([]string{}, in.ArgumentList("tokens")...)
+
+		if in.Option("name") != "" {
+			tokens = append(tokens, "--name="+in.Option("name"), "--path="+in.Option("path"))
+		}
+
+		assert.Equal(t, c.expected, tokens, c.input)
+	}
+}
+
+func TestStringInput(t *testing.T) {
+	var in input.InputInterface = input.NewStringInput(`deploy "my app" -fe prod`)
+
+	in.Bind(
+		*definition.New().
+			AddArgument(*argument.New("command", argument.Required)).
+			AddArgument(*argument.New("name", argument.Required)).
+			AddOption(*option.New("force", option.None).SetShortcut("f")).
+			AddOption(*option.New("env", option.Required).SetShortcut("e")),
+	)
+	in.Validate()
+
+	assert.Equal(t, "deploy", in.FirstArgument())
+	assert.Equal(t, "my app", in.Argument("name"))
+	assert.True(t, in.OptionBool("force"))
+	assert.Equal(t, "prod", in.Option("env"))
+	assert.Equal(t, "prod", in.ParameterOption([]string{"-e"}, "", false))
+
+	// reported when parsing, not by the constructor
+	in = input.NewStringInput(`deploy "my app`)
+
+	assert.PanicsWithError(t, `unterminated quote in 'deploy "my app'`, func() {
+		in.Bind(*definition.New())
+	})
+}
@@ -0,0 +1,88 @@
+package input
+
+import (
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/constraint"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type commonOptions struct {
+	Env string `console:"shortcut=e,desc=The environment, dev or prod,enum=dev|prod"`
+}
+
+type deployOptions struct {
+	commonOptions
+
+	Target  string        `console:"argument=target,required,desc=Where to deploy"`
+	Hosts   []string      `console:"argument=hosts"`
+	DryRun  bool          `console:"option=dry-run,shortcut=d,desc=Simulate the deployment"`
+	Retries int           `console:"min=0,max=10"`
+	Timeout time.Duration `console:"default=30s"`
+	Ports   []uint16      `console:"option=port"`
+	Ratio   float64       `console:""`
+	Ignored string
+}
+
+func TestStructDefinition(t *testing.T) {
+	def := input.StructDefinition(&deployOptions{Retries: 3})
+
+	assert.Equal(t, []string{"target", "hosts"}, def.ArgumentsOrder())
+	assert.Equal(t, []string{"env", "dry-run", "retries", "timeout", "port", "ratio"}, def.OptionsOrder())
+
+	assert.True(t, def.Argument("target").IsRequired())
+	assert.True(t, def.Argument("hosts").IsList())
+	assert.Equal(t, "Where to deploy", def.Argument("target").Description())
+
+	assert.Equal(t, "The environment, dev or prod", def.Option("env").Description())
+	assert.Equal(t, []string{"dev", "prod"}, def.Option("env").Constraint().AllowedValues())
+	assert.Equal(t, "d", def.Option("dry-run").Shortcut())
+	assert.False(t, def.Option("dry-run").IsAcceptValue())
+	assert.Equal(t, "3", def.Option("retries").Default())
+	assert.Equal(t, constraint.TypeInt, def.Option("retries").Constraint().Type())
+	assert.Equal(t, "30s", def.Option("timeout").Default())
+	assert.True(t, def.Option("port").IsList())
+}
+
+func TestBindStruct(t *testing.T) {
+	opts := &deployOptions{Retries: 3}
+
+	in := input.NewArgvInput([]string{
+		"cli.php", "prod-cluster", "a.local", "b.local",
+		"-e", "prod", "-d", "--timeout=1m", "--port=80", "--port=443", "--ratio=0.5",
+	})
+	in.Bind(*input.StructDefinition(opts))
+	in.Validate()
+
+	input.BindStruct(in, opts)
+
+	assert.Equal(t, "prod-cluster", opts.Target)
+	assert.Equal(t, []string{"a.local", "b.local"}, opts.Hosts)
+	assert.Equal(t, "prod", opts.Env)
+	assert.True(t, opts.DryRun)
+	assert.Equal(t, 3, opts.Retries)
+	assert.Equal(t, time.Minute, opts.Timeout)
+	assert.Equal(t, []uint16{80, 443}, opts.Ports)
+	assert.Equal(t, 0.5, opts.Ratio)
+}
+
+func TestBindStructErrors(t *testing.T) {
+	assert.Panics(t, func() {
+		input.StructDefinition(deployOptions{})
+	})
+
+	assert.Panics(t, func() {
+		input.StructDefinition(&struct {
+			Since time.Time `console:"option=since"`
+		}{})
+	})
+
+	opts := &deployOptions{}
+	in := input.NewArgvInput([]string{"cli.php", "prod", "--port=http"})
+	in.Bind(*input.StructDefinition(opts))
+
+	assert.PanicsWithError(t, "the '--port' option expects an integer, 'http' given", func() {
+		input.BindStruct(in, opts)
+	})
+}
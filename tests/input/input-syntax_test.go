@@ -0,0 +1,107 @@
+package input
+
+import (
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func syntaxInput(syntax definition.Syntax, argv ...string) *input.ArgvInput {
+	in := input.NewArgvInput(append([]string{"cli.php"}, argv...))
+
+	in.Bind(
+		*definition.New().
+			SetSyntax(syntax).
+			AddArgument(*argument.New("files", argument.Optional|argument.List)).
+			AddOption(*option.New("verbose", option.None).SetShortcut("v")).
+			AddOption(*option.New("version", option.None)).
+			AddOption(*option.New("output", option.Required).SetShortcut("o")).
+			AddOption(*option.New("color", option.Optional)).
+			AddOption(*option.New("cache", option.Negatable)).
+			AddOption(*option.New("exclude", option.Optional|option.List).SetShortcut("e")),
+	)
+
+	return in
+}
+
+func TestDefaultSyntax(t *testing.T) {
+	assert.Equal(t, definition.DefaultSyntax(), definition.New().Syntax())
+
+	in := syntaxInput(definition.DefaultSyntax(), "a", "-o", "out.txt", "b", "--color", "always", "-e", "x,y", "-e", "z")
+
+	assert.Equal(t, []string{"a", "b"}, in.ArgumentList("files"))
+	assert.Equal(t, "out.txt", in.Option("output"))
+	assert.Equal(t, "always", in.Option("color"))
+	assert.Equal(t, []string{"x,y", "z"}, in.OptionList("exclude"))
+
+	assert.PanicsWithError(t, "the '--verb' option does not exist. Did you mean '--verbose'?", func() {
+		syntaxInput(definition.DefaultSyntax(), "--verb")
+	})
+}
+
+func TestGnuSyntax(t *testing.T) {
+	in := syntaxInput(definition.GnuSyntax(), "a", "--out", "out.txt", "--verb", "--no-c", "b", "-ex,y", "--exc=z")
+
+	assert.Equal(t, []string{"a", "b"}, in.ArgumentList("files"))
+	assert.Equal(t, "out.txt", in.Option("output"))
+	assert.Equal(t, option.Defined, in.Option("verbose"))
+	assert.False(t, in.OptionBool("cache"))
+	assert.Equal(t, []string{"x", "y", "z"}, in.OptionList("exclude"))
+
+	// optional values must be attached
+	in = syntaxInput(definition.GnuSyntax(), "--color", "always")
+	assert.Equal(t, "", in.Option("color"))
+	assert.Equal(t, []string{"always"}, in.ArgumentList("files"))
+
+	assert.PanicsWithError(t, "the '--ver' option is ambiguous, it could be '--verbose', '--version'", func() {
+		syntaxInput(definition.GnuSyntax(), "--ver")
+	})
+
+	assert.PanicsWithError(t, "the '--unknown' option does not exist", func() {
+		syntaxInput(definition.GnuSyntax(), "--unknown")
+	})
+}
+
+func TestPosixSyntax(t *testing.T) {
+	in := syntaxInput(definition.PosixSyntax(), "-v", "a", "-o", "out.txt", "--", "-b")
+
+	assert.Equal(t, option.Defined, in.Option("verbose"))
+	assert.Equal(t, "", in.Option("output"))
+	assert.Equal(t, []string{"a", "-o", "out.txt", "--", "-b"}, in.ArgumentList("files"))
+}
+
+func TestRepeatedLists(t *testing.T) {
+	syntax := definition.DefaultSyntax()
+	syntax.RepeatedLists = false
+	syntax.ListSeparator = ";"
+
+	in := syntaxInput(syntax, "--exclude=a;b")
+	assert.Equal(t, []string{"a", "b"}, in.OptionList("exclude"))
+
+	assert.PanicsWithError(t, "the '--exclude' option cannot be given more than once", func() {
+		syntaxInput(syntax, "-e", "a", "-e", "b")
+	})
+}
+
+func TestResponseFiles(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "args.txt")
+	assert.Nil(t, os.WriteFile(file, []byte("--output 'my file.txt'\n-v\nb\n"), 0644))
+
+	in := syntaxInput(definition.GnuSyntax(), "a", "@"+file, "--", "@"+file)
+
+	assert.Equal(t, "my file.txt", in.Option("output"))
+	assert.Equal(t, option.Defined, in.Option("verbose"))
+	assert.Equal(t, []string{"a", "b", "@" + file}, in.ArgumentList("files"))
+
+	in = syntaxInput(definition.DefaultSyntax(), "@"+file)
+	assert.Equal(t, []string{"@" + file}, in.ArgumentList("files"))
+
+	assert.PanicsWithError(t, "cannot read the response file 'missing.txt'", func() {
+		syntaxInput(definition.GnuSyntax(), "@missing.txt")
+	})
+}
@@ -0,0 +1,88 @@
+package input
+
+import (
+	"errors"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func typedInput(argv ...string) *input.ArgvInput {
+	in := input.NewArgvInput(append([]string{"cli.php"}, argv...))
+
+	in.Bind(
+		*definition.New().
+			AddArgument(*argument.New("count", argument.Optional)).
+			AddArgument(*argument.New("ratios", argument.Optional|argument.List)).
+			AddOption(*option.New("retries", option.Required).SetDefault("3")).
+			AddOption(*option.New("ratio", option.Optional)).
+			AddOption(*option.New("timeout", option.Optional)).
+			AddOption(*option.New("since", option.Optional)).
+			AddOption(*option.New("enabled", option.Optional)).
+			AddOption(*option.New("force", option.None)).
+			AddOption(*option.New("ports", option.Optional|option.List)),
+	)
+
+	return in
+}
+
+func TestTypedAccessors(t *testing.T) {
+	in := typedInput(
+		"12", "0.5", "1.5",
+		"--ratio=0.75",
+		"--timeout=1m30s",
+		"--since=2023-03-09",
+		"--enabled=yes",
+		"--force",
+		"--ports=80", "--ports=443",
+	)
+
+	assert.Equal(t, 12, in.ArgumentInt("count"))
+	assert.Equal(t, []float64{0.5, 1.5}, in.ArgumentFloatList("ratios"))
+	assert.Equal(t, 3, in.OptionInt("retries"))
+	assert.Equal(t, 0.75, in.OptionFloat("ratio"))
+	assert.Equal(t, 90*time.Second, in.OptionDuration("timeout"))
+	assert.Equal(t, time.Date(2023, 3, 9, 0, 0, 0, 0, time.UTC), in.OptionTime("since", "2006-01-02"))
+	assert.True(t, in.OptionBool("enabled"))
+	assert.True(t, in.OptionBool("force"))
+	assert.Equal(t, []int{80, 443}, in.OptionIntList("ports"))
+}
+
+func TestTypedAccessorsEmptyValues(t *testing.T) {
+	in := typedInput()
+
+	assert.Equal(t, 0, in.ArgumentInt("count"))
+	assert.Equal(t, time.Duration(0), in.OptionDuration("timeout"))
+	assert.False(t, in.OptionBool("enabled"))
+	assert.False(t, in.OptionBool("force"))
+	assert.Equal(t, []int{}, in.OptionIntList("ports"))
+}
+
+func TestTypedAccessorsErrors(t *testing.T) {
+	in := typedInput("twelve", "--retries=many", "--ports=80", "--ports=http")
+
+	assert.PanicsWithError(t, "the '--retries' option expects an integer, 'many' given", func() {
+		in.OptionInt("retries")
+	})
+
+	assert.PanicsWithError(t, "the 'count' argument expects an integer, 'twelve' given", func() {
+		in.ArgumentInt("count")
+	})
+
+	assert.PanicsWithError(t, "the '--ports' option expects integers, 'http' given", func() {
+		in.OptionIntList("ports")
+	})
+
+	defer func() {
+		var invalid *input.InvalidValueError
+
+		assert.True(t, errors.As(recover().(error), &invalid))
+		assert.Equal(t, "retries", invalid.Name)
+	}()
+
+	in.OptionInt("retries")
+}
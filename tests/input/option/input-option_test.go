@@ -1,6 +1,7 @@
 package option
 
 import (
+	"github.com/DrSmithFr/go-console/input/constraint"
 	"github.com/DrSmithFr/go-console/input/option"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -183,3 +184,15 @@ func TestEquals(t *testing.T) {
 
 	assert.False(t, opt7.Equals(*opt8))
 }
+
+func TestConstraint(t *testing.T) {
+	opt := option.New("level", option.Required).
+		SetConstraint(constraint.Enum("debug", "info", "warn"))
+
+	assert.Equal(t, constraint.TypeEnum, opt.Constraint().Type())
+	assert.Equal(t, []string{"debug"}, opt.Complete("d", nil))
+
+	assert.Panics(t, func() {
+		option.New("force", option.None).SetConstraint(constraint.Bool())
+	})
+}
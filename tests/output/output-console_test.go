@@ -0,0 +1,67 @@
+package output
+
+import (
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/DrSmithFr/go-console/verbosity"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+// redirect replace stdout and stderr by files, returning a function reading them
+func redirect(t *testing.T) func() (string, string) {
+	stdout, _ := os.CreateTemp(t.TempDir(), "stdout")
+	stderr, _ := os.CreateTemp(t.TempDir(), "stderr")
+
+	previousOut, previousErr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = stdout, stderr
+
+	t.Cleanup(func() {
+		os.Stdout, os.Stderr = previousOut, previousErr
+	})
+
+	return func() (string, string) {
+		out, _ := os.ReadFile(stdout.Name())
+		err, _ := os.ReadFile(stderr.Name())
+		return string(out), string(err)
+	}
+}
+
+func TestErrorOutput(t *testing.T) {
+	clearDecorationEnv(t)
+	read := redirect(t)
+
+	out := output.NewCliOutput(true, nil)
+	out.Println("<info>data</info>")
+	out.ErrorOutput().Println("<error>failed</error>")
+
+	stdout, stderr := read()
+
+	assert.Equal(t, "\x1b[32mdata\x1b[39m\n", stdout)
+	assert.Equal(t, "failed\n", stderr)
+
+	// stderr is not a terminal
+	assert.True(t, out.IsDecorated())
+	assert.False(t, out.ErrorOutput().IsDecorated())
+}
+
+func TestErrorOutputVerbosity(t *testing.T) {
+	read := redirect(t)
+
+	out := output.NewCliOutput(false, nil)
+	out.SetVerbosity(verbosity.Quiet)
+	out.ErrorOutput().Println("failed")
+
+	assert.Equal(t, verbosity.Quiet, out.ErrorOutput().Verbosity())
+
+	buffered := output.NewBufferedOutput(false, nil)
+	out.SetErrorOutput(buffered)
+	out.SetVerbosity(verbosity.Normal)
+	out.ErrorOutput().Println("stored")
+
+	stdout, stderr := read()
+
+	assert.Equal(t, "", stdout)
+	assert.Equal(t, "", stderr)
+	assert.Equal(t, "stored\n", buffered.Fetch())
+}
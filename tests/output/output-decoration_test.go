@@ -0,0 +1,63 @@
+package output
+
+import (
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+// clearDecorationEnv unset the environment variables changing the decoration
+func clearDecorationEnv(t *testing.T) {
+	for _, name := range []string{
+		"FORCE_COLOR", "NO_COLOR", "CLICOLOR_FORCE", "CLICOLOR", "TERM",
+		"GITHUB_ACTIONS", "GITLAB_CI", "BUILDKITE", "CIRCLECI",
+	} {
+		t.Setenv(name, "")
+	}
+}
+
+func TestDetectDecoration(t *testing.T) {
+	file, _ := os.CreateTemp(t.TempDir(), "output")
+
+	cases := []struct {
+		env      map[string]string
+		expected bool
+	}{
+		{map[string]string{}, false},
+		{map[string]string{"FORCE_COLOR": "1"}, true},
+		{map[string]string{"FORCE_COLOR": "true", "NO_COLOR": "1"}, true},
+		{map[string]string{"FORCE_COLOR": "0", "GITHUB_ACTIONS": "true"}, false},
+		{map[string]string{"NO_COLOR": "1", "CLICOLOR_FORCE": "1"}, false},
+		{map[string]string{"CLICOLOR_FORCE": "1"}, true},
+		{map[string]string{"CLICOLOR": "0", "GITHUB_ACTIONS": "true"}, false},
+		{map[string]string{"CLICOLOR": "1"}, false},
+		{map[string]string{"TERM": "dumb", "GITLAB_CI": "true"}, false},
+		{map[string]string{"GITHUB_ACTIONS": "true"}, true},
+	}
+
+	for _, c := range cases {
+		clearDecorationEnv(t)
+
+		for name, value := range c.env {
+			t.Setenv(name, value)
+		}
+
+		assert.Equal(t, c.expected, output.DetectDecoration(file), c.env)
+	}
+}
+
+func TestConsoleOutputDecoration(t *testing.T) {
+	clearDecorationEnv(t)
+	redirect(t)
+
+	out := output.NewConsoleOutput(nil)
+	assert.False(t, out.IsDecorated())
+	assert.False(t, out.ErrorOutput().IsDecorated())
+
+	t.Setenv("FORCE_COLOR", "1")
+
+	out = output.NewConsoleOutput(nil)
+	assert.True(t, out.IsDecorated())
+	assert.True(t, out.ErrorOutput().IsDecorated())
+}
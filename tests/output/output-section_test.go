@@ -0,0 +1,102 @@
+package output
+
+import (
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestSectionAppend(t *testing.T) {
+	read := redirect(t)
+
+	out := output.NewCliOutput(true, nil)
+	section := out.Section()
+	section.Println("<info>first</info>")
+	section.Print("second")
+
+	stdout, _ := read()
+
+	assert.Equal(t, "\x1b[32mfirst\x1b[39m\nsecond\n", stdout)
+	assert.Equal(t, "\x1b[32mfirst\x1b[39m\nsecond\n", section.Content())
+}
+
+func TestSectionOverwrite(t *testing.T) {
+	read := redirect(t)
+
+	out := output.NewCliOutput(true, nil)
+	section := out.Section()
+	section.Println("loading")
+	section.Overwrite("done")
+
+	stdout, _ := read()
+
+	assert.Equal(t, "loading\n\x1b[1A\x1b[0Jdone\n", stdout)
+	assert.Equal(t, "done\n", section.Content())
+}
+
+func TestSectionClearLines(t *testing.T) {
+	read := redirect(t)
+
+	out := output.NewCliOutput(true, nil)
+	section := out.Section()
+	section.Println("a\nb\nc")
+	section.ClearLines(2)
+
+	stdout, _ := read()
+
+	assert.Equal(t, "a\nb\nc\n\x1b[2A\x1b[0J", stdout)
+	assert.Equal(t, "a\n", section.Content())
+
+	section.Clear()
+	assert.Equal(t, "", section.Content())
+}
+
+func TestSectionRewritesNextSections(t *testing.T) {
+	read := redirect(t)
+
+	out := output.NewCliOutput(true, nil)
+	first := out.Section()
+	second := out.Section()
+
+	first.Println("one")
+	second.Println("two")
+	first.Overwrite("1")
+
+	stdout, _ := read()
+
+	// the second section is erased with the first one, then written back
+	assert.Equal(t, "one\ntwo\n\x1b[2A\x1b[0Jtwo\n\x1b[1A\x1b[0J1\ntwo\n", stdout)
+	assert.Equal(t, "1\n", first.Content())
+	assert.Equal(t, "two\n", second.Content())
+}
+
+func TestSectionWrappedLines(t *testing.T) {
+	t.Setenv("COLUMNS", "10")
+	read := redirect(t)
+
+	out := output.NewCliOutput(true, nil)
+	section := out.Section()
+
+	// 25 visible characters take 3 lines of 10 columns, decoration excluded
+	section.Println("<comment>" + strings.Repeat("x", 25) + "</comment>")
+	section.Clear()
+
+	stdout, _ := read()
+
+	assert.True(t, strings.HasSuffix(stdout, "\x1b[3A\x1b[0J"))
+}
+
+func TestSectionNotDecorated(t *testing.T) {
+	read := redirect(t)
+
+	out := output.NewCliOutput(false, nil)
+	section := out.Section()
+	section.Print("plain")
+	section.Overwrite("text")
+
+	stdout, _ := read()
+
+	// without ANSI codes nothing can be erased
+	assert.Equal(t, "plaintext\n", stdout)
+}
@@ -0,0 +1,223 @@
+package progress
+
+import (
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/DrSmithFr/go-console/progress"
+	"github.com/DrSmithFr/go-console/verbosity"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"strings"
+	"testing"
+)
+
+const erase = "\x1b[1G\x1b[2K"
+
+// newBar creates a bar redrawn at each step
+func newBar(out output.OutputInterface, max int) *progress.ProgressBar {
+	return progress.NewProgressBar(out, max).
+		SetRedrawFrequency(1).
+		MinSecondsBetweenRedraws(0)
+}
+
+func TestProgressBarAdvance(t *testing.T) {
+	out := output.NewBufferedOutput(true, nil)
+
+	bar := newBar(out, 10).SetBarWidth(10)
+	bar.Start(0)
+	bar.Advance(1)
+	bar.Advance(4)
+	bar.Finish()
+
+	assert.Equal(
+		t,
+		"  0/10 [>---------]   0%"+
+			erase+"  1/10 [=>--------]  10%"+
+			erase+"  5/10 [=====>----]  50%"+
+			erase+" 10/10 [==========] 100%",
+		out.Fetch(),
+	)
+}
+
+func TestProgressBarCustomFormat(t *testing.T) {
+	out := output.NewBufferedOutput(true, nil)
+
+	bar := newBar(out, 3).
+		SetFormat("%message% %current:3s%/%max% [%bar%] %percent%% %file%").
+		SetBarWidth(6).
+		SetBarCharacter("#").
+		SetEmptyBarCharacter(".").
+		SetProgressCharacter("").
+		SetMessage("Importing").
+		SetNamedMessage("file", "users.csv")
+
+	bar.Start(0)
+
+	assert.Equal(t, "Importing   0/3 [......] 0% users.csv", out.Fetch())
+
+	bar.SetNamedMessage("file", "orders.csv")
+	bar.Advance(2)
+
+	assert.Equal(t, erase+"Importing   2/3 [####..] 66% orders.csv", out.Fetch())
+}
+
+func TestProgressBarPlaceholderFormatter(t *testing.T) {
+	out := output.NewBufferedOutput(true, nil)
+
+	bar := newBar(out, 4).
+		SetFormat("%current% %left% left").
+		SetPlaceholderFormatter("left", func(bar *progress.ProgressBar) string {
+			return strings.Repeat("*", bar.MaxSteps()-bar.Progress())
+		})
+
+	bar.Start(0)
+
+	assert.Equal(t, "0 **** left", out.Fetch())
+}
+
+func TestProgressBarUnknownMax(t *testing.T) {
+	out := output.NewBufferedOutput(true, nil)
+
+	bar := newBar(out, 0).SetBarWidth(4)
+	bar.Start(0)
+	bar.Advance(1)
+	bar.Advance(4)
+
+	assert.Equal(
+		t,
+		"    0 [>---]"+
+			erase+"    1 [=>--]"+
+			erase+"    5 [=>--]",
+		out.Fetch(),
+	)
+
+	// the maximum becomes the current step
+	bar.Finish()
+
+	assert.Equal(t, 5, bar.MaxSteps())
+	assert.Equal(t, erase+" 5/5 [====] 100%", out.Fetch())
+}
+
+func TestProgressBarStepWidth(t *testing.T) {
+	out := output.NewBufferedOutput(true, nil)
+
+	// the maximum grows when the progress goes past it
+	bar := newBar(out, 9).SetFormat("%current%/%max%")
+	bar.Start(0)
+	bar.SetProgress(12)
+	bar.SetProgress(3)
+
+	assert.Equal(t, "0/9"+erase+"12/12"+erase+" 3/12", out.Fetch())
+
+	// without maximum, the width follows the largest step
+	bar = newBar(out, 0).SetFormat("%current%")
+	bar.Start(0)
+	bar.SetProgress(12345)
+	bar.SetProgress(12346)
+
+	assert.Equal(t, "   0"+erase+"12345"+erase+"12346", out.Fetch())
+
+	bar.SetMaxSteps(100000)
+	bar.SetProgress(3)
+
+	assert.Equal(t, erase+"     3", out.Fetch())
+}
+
+func TestProgressBarThrottling(t *testing.T) {
+	out := output.NewBufferedOutput(true, nil)
+
+	// redrawn every 10% of the progress by default
+	bar := progress.NewProgressBar(out, 100).MinSecondsBetweenRedraws(0).SetFormat("%current%")
+	bar.Start(0)
+
+	for i := 0; i < 25; i++ {
+		bar.Advance(1)
+	}
+
+	assert.Equal(t, "  0"+erase+" 10"+erase+" 20", out.Fetch())
+
+	// redraws sooner than the minimum interval are skipped, except the last one
+	bar = progress.NewProgressBar(out, 3).SetRedrawFrequency(1).SetFormat("%current%")
+	bar.Start(0)
+	bar.Advance(1)
+	bar.Finish()
+
+	assert.Equal(t, "0"+erase+"3", out.Fetch())
+}
+
+func TestProgressBarNotDecorated(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	bar := progress.NewProgressBar(out, 20).SetBarWidth(10)
+	bar.Start(0)
+
+	for i := 0; i < 20; i++ {
+		bar.Advance(1)
+	}
+
+	bar.Finish()
+
+	// a line every 10% of the progress, without overwriting
+	lines := strings.Split(out.Fetch(), "\n")
+
+	assert.Len(t, lines, 11)
+	assert.Equal(t, "  0/20 [>---------]   0%", lines[0])
+	assert.Equal(t, "  2/20 [=>--------]  10%", lines[1])
+	assert.Equal(t, " 20/20 [==========] 100%", lines[10])
+}
+
+func TestProgressBarVerbosity(t *testing.T) {
+	out := output.NewBufferedOutput(true, nil)
+	out.SetVerbosity(verbosity.Quiet)
+
+	newBar(out, 10).Start(0)
+	assert.Equal(t, "", out.Fetch())
+
+	out.SetVerbosity(verbosity.Verbose)
+
+	newBar(out, 10).SetBarWidth(2).Start(0)
+	assert.Equal(t, "  0/10 [>-]   0% < 1 sec", out.Fetch())
+}
+
+func TestProgressBarClear(t *testing.T) {
+	out := output.NewBufferedOutput(true, nil)
+
+	bar := newBar(out, 10).SetFormat("%current%")
+	bar.Start(0)
+	bar.Clear()
+	bar.Display()
+
+	// the cleared line is not erased twice
+	assert.Equal(t, " 0"+erase+" 0", out.Fetch())
+}
+
+func TestProgressBarSections(t *testing.T) {
+	stdout, _ := os.CreateTemp(t.TempDir(), "stdout")
+	previous := os.Stdout
+	os.Stdout = stdout
+
+	t.Cleanup(func() {
+		os.Stdout = previous
+	})
+
+	out := output.NewCliOutput(true, nil)
+
+	first := newBar(out.Section(), 2).SetFormat("a %current%")
+	second := newBar(out.Section(), 2).SetFormat("b %current%")
+
+	first.Start(0)
+	second.Start(0)
+	first.Advance(1)
+	second.Advance(1)
+
+	content, _ := os.ReadFile(stdout.Name())
+
+	assert.Equal(
+		t,
+		"a 0\n"+
+			"b 0\n"+
+			"\x1b[2A\x1b[0Jb 0\n"+ // the first bar is cleared, the second written back
+			"\x1b[1A\x1b[0Ja 1\nb 0\n"+
+			"\x1b[1A\x1b[0Jb 1\n",
+		string(content),
+	)
+}
@@ -0,0 +1,214 @@
+package progress
+
+import (
+	"context"
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/DrSmithFr/go-console/progress"
+	"github.com/DrSmithFr/go-console/verbosity"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newIndicator creates an indicator which is only advanced by hand
+func newIndicator(out output.OutputInterface) *progress.ProgressIndicator {
+	return progress.NewProgressIndicator(out).SetInterval(time.Hour)
+}
+
+func TestProgressIndicatorAdvance(t *testing.T) {
+	out := output.NewBufferedOutput(true, nil)
+
+	indicator := newIndicator(out)
+	indicator.Start("Loading...")
+	indicator.Advance()
+	indicator.SetMessage("Parsing...")
+	indicator.Advance()
+	indicator.Finish("Done")
+
+	assert.Equal(
+		t,
+		erase+" - Loading..."+
+			erase+" \\ Loading..."+
+			erase+" \\ Parsing..."+
+			erase+" | Parsing..."+
+			erase+" | Done\n",
+		out.Fetch(),
+	)
+}
+
+func TestProgressIndicatorFormat(t *testing.T) {
+	out := output.NewBufferedOutput(true, nil)
+
+	indicator := newIndicator(out).
+		SetFrames("a", "b").
+		SetFormat("[%indicator%] %message:-5s%|")
+
+	indicator.Start("go")
+	indicator.Advance()
+	indicator.Advance()
+	indicator.Stop()
+
+	assert.Equal(t, erase+"[a] go   |"+erase+"[b] go   |"+erase+"[a] go   |"+erase, out.Fetch())
+
+	assert.Panics(t, func() {
+		indicator.SetFrames("a")
+	})
+}
+
+func TestProgressIndicatorVerbose(t *testing.T) {
+	out := output.NewBufferedOutput(true, nil)
+	out.SetVerbosity(verbosity.Verbose)
+
+	newIndicator(out).Start("Loading...")
+
+	assert.Equal(t, erase+" - Loading... (< 1 sec)", out.Fetch())
+}
+
+func TestProgressIndicatorTicker(t *testing.T) {
+	out := output.NewBufferedOutput(true, nil)
+
+	indicator := progress.NewProgressIndicator(out).SetInterval(time.Millisecond)
+	indicator.Start("Loading...")
+
+	time.Sleep(20 * time.Millisecond)
+
+	indicator.Stop()
+	indicator.Stop()
+
+	display := out.Fetch()
+
+	assert.Contains(t, display, " \\ Loading...")
+	assert.True(t, strings.HasSuffix(display, erase))
+
+	// nothing is written once stopped
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, "", out.Fetch())
+
+	assert.NotPanics(t, func() {
+		indicator.Start("Again")
+		indicator.Stop()
+	})
+}
+
+func TestProgressIndicatorPrintln(t *testing.T) {
+	out := output.NewBufferedOutput(true, nil)
+
+	indicator := newIndicator(out)
+	indicator.Println("before")
+	indicator.Start("Loading...")
+	indicator.Println("found 3 files")
+	indicator.Finish("Done")
+
+	assert.Equal(
+		t,
+		"before\n"+
+			erase+" - Loading..."+
+			erase+"found 3 files\n"+
+			erase+" - Loading..."+
+			erase+" - Done\n",
+		out.Fetch(),
+	)
+}
+
+func TestProgressIndicatorSectionPrintln(t *testing.T) {
+	stdout, _ := os.CreateTemp(t.TempDir(), "stdout")
+	previous := os.Stdout
+	os.Stdout = stdout
+
+	t.Cleanup(func() {
+		os.Stdout = previous
+	})
+
+	section := output.NewCliOutput(true, nil).Section()
+
+	indicator := newIndicator(section)
+	indicator.Start("Loading...")
+	indicator.Println("found 3 files")
+	indicator.Advance()
+	indicator.Finish("Done")
+
+	// only the indicator line is redrawn
+	assert.Equal(t, "found 3 files\n \\ Done\n", section.Content())
+}
+
+// run with -race, the ticker goroutine redraws while the runner updates the indicator
+func TestProgressIndicatorConcurrentUpdates(t *testing.T) {
+	out := output.NewBufferedOutput(true, nil)
+
+	indicator := progress.NewProgressIndicator(out).SetInterval(time.Millisecond)
+	indicator.Start("Loading...")
+
+	for step := 0; step < 50; step++ {
+		indicator.SetMessage("Step " + strconv.Itoa(step))
+		indicator.Println("done " + strconv.Itoa(step))
+		time.Sleep(100 * time.Microsecond)
+	}
+
+	indicator.Finish("Done")
+
+	display := out.Fetch()
+
+	assert.Contains(t, display, "done 49\n")
+	assert.True(t, strings.HasSuffix(display, " Done\n"))
+}
+
+func TestProgressIndicatorNotDecorated(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	indicator := newIndicator(out)
+	indicator.Start("Loading...")
+	indicator.Advance()
+	indicator.SetMessage("Parsing...")
+	indicator.Finish("Done")
+
+	assert.Equal(t, " Loading...\n Parsing...\n Done\n", out.Fetch())
+}
+
+func TestProgressIndicatorQuiet(t *testing.T) {
+	out := output.NewBufferedOutput(true, nil)
+	out.SetVerbosity(verbosity.Quiet)
+
+	indicator := newIndicator(out)
+	indicator.Start("Loading...")
+	indicator.Advance()
+	indicator.Finish("Done")
+
+	assert.Equal(t, "", out.Fetch())
+}
+
+func TestProgressIndicatorStatus(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	script := &go_console.Script{
+		Name:   "import",
+		Input:  input.NewArgvInput([]string{"import"}),
+		Output: out,
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			indicator := cmd.CreateProgressIndicator().SetInterval(time.Hour)
+
+			indicator.Start("Importing...")
+			indicator.Success("Imported")
+
+			indicator.Start("Exporting...")
+			indicator.Fail("Export failed")
+
+			return go_console.ExitSuccess
+		},
+	}
+
+	code, err := script.Execute(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+
+	display := out.Fetch()
+
+	assert.Contains(t, display, " Importing...\n")
+	assert.Contains(t, display, "[OK] Imported")
+	assert.Contains(t, display, "[ERROR] Export failed")
+}
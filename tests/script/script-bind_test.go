@@ -0,0 +1,69 @@
+package script
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/tester"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type greetOptions struct {
+	Name   string `console:"argument=name,required,desc=Who do you want to greet?"`
+	Times  int    `console:"option=times,shortcut=t,min=1,desc=How many times"`
+	Shout  bool   `console:"shortcut=s"`
+	Prefix string `console:"default=Hello"`
+}
+
+func newBoundScript(opts *greetOptions, received *greetOptions) *go_console.Script {
+	return &go_console.Script{
+		Name: "greet",
+		Bind: opts,
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			*received = *opts
+			return go_console.ExitSuccess
+		},
+	}
+}
+
+func TestScriptBind(t *testing.T) {
+	var received greetOptions
+
+	result := tester.
+		NewScriptTester(newBoundScript(&greetOptions{Times: 1}, &received)).
+		Execute([]string{"John", "-t", "3", "--shout"})
+
+	assert.Nil(t, result.Error)
+	assert.Equal(t, greetOptions{Name: "John", Times: 3, Shout: true, Prefix: "Hello"}, received)
+
+	result = tester.
+		NewScriptTester(newBoundScript(&greetOptions{Times: 1}, &received)).
+		Execute([]string{"John", "--times=0"})
+
+	assert.EqualError(t, result.Error, "the '--times' option expects a value >= 1, '0' given")
+
+	result = tester.
+		NewScriptTester(newBoundScript(&greetOptions{Times: 1}, &received)).
+		Execute([]string{"--help"})
+	assert.Contains(t, result.Display(), "Who do you want to greet?")
+	assert.Contains(t, result.Display(), "[-t|--times[=<int>]]")
+}
+
+func TestScriptBindNegatable(t *testing.T) {
+	opts := &struct {
+		Cache bool `console:"desc=Use the cache"`
+		Color bool `console:"negatable"`
+	}{Cache: true}
+
+	script := &go_console.Script{
+		Name:   "build",
+		Bind:   opts,
+		Runner: func(cmd *go_console.Script) go_console.ExitCode { return go_console.ExitSuccess },
+	}
+
+	result := tester.NewScriptTester(script).Execute([]string{"--no-cache", "--color"})
+
+	assert.Nil(t, result.Error)
+	assert.False(t, opts.Cache)
+	assert.True(t, opts.Color)
+	assert.Contains(t, result.Input.Definition().Synopsis(false), "[--[no-]cache] [--[no-]color]")
+}
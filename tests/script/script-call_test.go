@@ -0,0 +1,44 @@
+package script
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/tester"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCallSubScript(t *testing.T) {
+	var codes []go_console.ExitCode
+
+	script := &go_console.Script{
+		Name: "app",
+		Scripts: []*go_console.Script{
+			{
+				Name: "db",
+				Scripts: []*go_console.Script{
+					{
+						Name: "migrate",
+						Runner: func(cmd *go_console.Script) go_console.ExitCode {
+							cmd.PrintText("migrating " + cmd.Name)
+							return go_console.ExitSuccess
+						},
+					},
+				},
+			},
+		},
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			for _, name := range []string{"db migrate", "db", "unknown"} {
+				code, _ := cmd.Call(name, nil)
+				codes = append(codes, code)
+			}
+
+			return go_console.ExitSuccess
+		},
+	}
+
+	result := tester.NewScriptTester(script).Execute([]string{})
+
+	assert.Nil(t, result.Error)
+	assert.Contains(t, result.Display(), "migrating migrate")
+	assert.Equal(t, []go_console.ExitCode{go_console.ExitSuccess, go_console.ExitInvalid, go_console.ExitInvalid}, codes)
+}
@@ -0,0 +1,118 @@
+package script
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/tester"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "app.yaml")
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func newConfigScript(paths ...string) *go_console.Script {
+	return &go_console.Script{
+		Name: "deploy",
+		Options: []go_console.Option{
+			{Name: "dsn", Value: option.Required, DefaultValue: "sqlite"},
+			{Name: "region", Value: option.Required, DefaultValue: "eu", Env: "APP_REGION"},
+			{Name: "hosts", Value: option.Optional | option.List},
+			{Name: "dry-run", Value: option.None},
+		},
+		ConfigFile: &go_console.ConfigFile{Paths: paths},
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			return go_console.ExitSuccess
+		},
+	}
+}
+
+func TestConfigPrecedence(t *testing.T) {
+	path := writeConfig(t, "dsn: mysql://file\nregion: us\nhosts: [a.local, b.local]\ndry-run: true\n")
+	t.Setenv("APP_REGION", "asia")
+
+	result := tester.
+		NewScriptTester(newConfigScript()).
+		Execute([]string{"--config=" + path})
+
+	assert.Nil(t, result.Error)
+	assert.Equal(t, "mysql://file", result.Input.Option("dsn"))
+	assert.Equal(t, input.SourceFile, result.Input.OptionSource("dsn"))
+	assert.Equal(t, "asia", result.Input.Option("region"))
+	assert.Equal(t, input.SourceEnv, result.Input.OptionSource("region"))
+	assert.Equal(t, []string{"a.local", "b.local"}, result.Input.OptionList("hosts"))
+	assert.Equal(t, option.Defined, result.Input.Option("dry-run"))
+
+	result = tester.
+		NewScriptTester(newConfigScript()).
+		Execute([]string{"--config=" + path, "--dsn=pgsql://cli"})
+
+	assert.Equal(t, "pgsql://cli", result.Input.Option("dsn"))
+	assert.Equal(t, input.SourceCli, result.Input.OptionSource("dsn"))
+}
+
+func TestConfigByConvention(t *testing.T) {
+	path := writeConfig(t, "dsn: mysql://file\n")
+
+	result := tester.
+		NewScriptTester(newConfigScript("missing.yaml", path)).
+		Execute([]string{})
+
+	assert.Equal(t, "mysql://file", result.Input.Option("dsn"))
+
+	result = tester.
+		NewScriptTester(newConfigScript("missing.yaml")).
+		Execute([]string{})
+
+	assert.Equal(t, "sqlite", result.Input.Option("dsn"))
+	assert.Equal(t, input.SourceDefault, result.Input.OptionSource("dsn"))
+}
+
+func TestConfigErrors(t *testing.T) {
+	result := tester.
+		NewScriptTester(newConfigScript()).
+		Execute([]string{"--config=missing.yaml"})
+
+	assert.Equal(t, go_console.ExitInvalid, result.ExitCode)
+	assert.NotNil(t, result.Error)
+}
+
+func TestConfigDebugReport(t *testing.T) {
+	path := writeConfig(t, "dsn: mysql://file\n")
+
+	result := tester.
+		NewScriptTester(newConfigScript()).
+		Execute([]string{"--config=" + path, "-vvv"})
+
+	assert.Contains(t, result.Display(), "Configuration file: "+path)
+	assert.Contains(t, result.Display(), `--dsn = "mysql://file" (file)`)
+	assert.Contains(t, result.Display(), `--region = "eu" (default)`)
+}
+
+func TestCommandConfig(t *testing.T) {
+	path := writeConfig(t, "dsn: mysql://file\n")
+	script := newConfigScript()
+	script.ConfigFile = nil
+
+	cmd := &go_console.Command{
+		ConfigFile: &go_console.ConfigFile{Option: "settings"},
+		Scripts:    []*go_console.Script{script},
+	}
+
+	result := tester.
+		NewCommandTester(cmd).
+		Execute([]string{"--settings=" + path, "deploy"})
+
+	assert.Nil(t, result.Error)
+	assert.Equal(t, "mysql://file", result.Input.Option("dsn"))
+}
@@ -0,0 +1,52 @@
+package script
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/constraint"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/tester"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newConstraintScript() *go_console.Script {
+	return &go_console.Script{
+		Name: "serve",
+		Arguments: []go_console.Argument{
+			{Name: "port", Value: argument.Optional, Constraint: constraint.Int().Range(1, 65535)},
+		},
+		Options: []go_console.Option{
+			{Name: "level", Value: option.Required, DefaultValue: "info", Constraint: constraint.Enum("debug", "info", "warn")},
+			{Name: "timeout", Value: option.Optional | option.List, Constraint: constraint.Duration()},
+		},
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			return go_console.ExitSuccess
+		},
+	}
+}
+
+func TestConstraintsValidation(t *testing.T) {
+	result := tester.NewScriptTester(newConstraintScript()).Execute([]string{"8080", "--level=warn", "--timeout=1s"})
+	assert.Nil(t, result.Error)
+
+	result = tester.NewScriptTester(newConstraintScript()).Execute([]string{"--level=trace"})
+	assert.Equal(t, go_console.ExitInvalid, result.ExitCode)
+	assert.EqualError(t, result.Error, "the '--level' option expects one of debug, info, warn, 'trace' given")
+	assert.Contains(t, result.Display(), "[--level=<debug|info|warn>]")
+	assert.Contains(t, result.Display(), "[<port:int>]")
+
+	result = tester.NewScriptTester(newConstraintScript()).Execute([]string{"70000"})
+	assert.EqualError(t, result.Error, "the 'port' argument expects a value between 1 and 65535, '70000' given")
+
+	result = tester.NewScriptTester(newConstraintScript()).Execute([]string{"--timeout=1s", "--timeout=later"})
+	assert.EqualError(t, result.Error, "the '--timeout' option expects a duration, 'later' given")
+}
+
+func TestConstraintsHelp(t *testing.T) {
+	result := tester.NewScriptTester(newConstraintScript()).Execute([]string{"--help"})
+
+	assert.Contains(t, result.Display(), "[int, between 1 and 65535]")
+	assert.Contains(t, result.Display(), `[debug|info|warn] [default: "info"]`)
+	assert.Contains(t, result.Display(), "[--timeout[=<duration>]]")
+}
@@ -0,0 +1,33 @@
+package script
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/tester"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newDecorationScript() *go_console.Script {
+	return &go_console.Script{
+		Name: "export",
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			cmd.PrintText("<info>exported</info>")
+			return go_console.ExitSuccess
+		},
+	}
+}
+
+func TestAnsiOption(t *testing.T) {
+	result := tester.NewScriptTester(newDecorationScript()).Execute([]string{"--ansi"})
+	assert.Contains(t, result.Output(), "\x1b[32mexported\x1b[39m")
+
+	result = tester.NewScriptTester(newDecorationScript()).SetDecorated(true).Execute([]string{"--no-ansi"})
+	assert.Contains(t, result.Display(), "exported")
+	assert.NotContains(t, result.Output(), "\x1b[")
+
+	result = tester.NewScriptTester(newDecorationScript()).SetDecorated(true).Execute([]string{})
+	assert.Contains(t, result.Output(), "\x1b[32mexported\x1b[39m")
+
+	result = tester.NewScriptTester(newDecorationScript()).Execute([]string{"--help"})
+	assert.Contains(t, result.Display(), "--[no-]ansi")
+}
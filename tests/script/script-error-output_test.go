@@ -0,0 +1,77 @@
+package script
+
+import (
+	"context"
+	"errors"
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestErrorsOnErrorOutput(t *testing.T) {
+	stdout := output.NewBufferedOutput(false, nil)
+	stderr := output.NewBufferedOutput(false, nil)
+
+	script := &go_console.Script{
+		Name:   "export",
+		Input:  input.NewArgvInput([]string{"export"}),
+		Output: &consoleOutput{BufferedOutput: stdout, errorOutput: stderr},
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			cmd.PrintText("data")
+			cmd.PrintWarning("slow")
+			cmd.PrintCaution("careful")
+			cmd.PrintError("failed")
+			panic(errors.New("boom"))
+		},
+	}
+
+	code, err := script.Execute(context.Background())
+
+	assert.Equal(t, go_console.ExitError, code)
+	assert.EqualError(t, err, "boom")
+
+	assert.Equal(t, "data\n", stdout.Fetch())
+
+	display := stderr.Fetch()
+	assert.Contains(t, display, "[WARNING] slow")
+	assert.Contains(t, display, "! [CAUTION] careful")
+	assert.Contains(t, display, "[ERROR] failed")
+	assert.Contains(t, display, "[ERROR] boom")
+	assert.Contains(t, display, "Exception trace:")
+}
+
+func TestParsingErrorOnErrorOutput(t *testing.T) {
+	stdout := output.NewBufferedOutput(false, nil)
+	stderr := output.NewBufferedOutput(false, nil)
+
+	script := &go_console.Script{
+		Name:   "export",
+		Input:  input.NewArgvInput([]string{"export", "--unknown"}),
+		Output: &consoleOutput{BufferedOutput: stdout, errorOutput: stderr},
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			return go_console.ExitSuccess
+		},
+	}
+
+	code, _ := script.Execute(context.Background())
+
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.Equal(t, "", stdout.Fetch())
+	assert.Contains(t, stderr.Fetch(), "Usage:")
+}
+
+// consoleOutput a buffered output with a separate error output
+type consoleOutput struct {
+	*output.BufferedOutput
+	errorOutput output.OutputInterface
+}
+
+func (o *consoleOutput) ErrorOutput() output.OutputInterface {
+	return o.errorOutput
+}
+
+func (o *consoleOutput) SetErrorOutput(out output.OutputInterface) {
+	o.errorOutput = out
+}
@@ -0,0 +1,99 @@
+package script
+
+import (
+	"context"
+	"errors"
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExecuteTwice(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	script := &go_console.Script{
+		Name:   "greet",
+		Output: out,
+		Arguments: []go_console.Argument{
+			{Name: "name", Value: argument.Required},
+		},
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			cmd.PrintText("hello " + cmd.Input.Argument("name"))
+			return go_console.ExitSuccess
+		},
+	}
+
+	script.Input = input.NewArgvInput([]string{"greet", "john"})
+	code, err := script.Execute(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Contains(t, out.Fetch(), "hello john")
+
+	// the same input is parsed again
+	code, err = script.Execute(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Contains(t, out.Fetch(), "hello john")
+
+	script.Input = input.NewArgvInput([]string{"greet", "jane"})
+	code, err = script.Execute(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Contains(t, out.Fetch(), "hello jane")
+}
+
+func TestExecuteHandleRuntimeException(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	script := &go_console.Script{
+		Name:   "import",
+		Input:  input.NewArgvInput([]string{"import"}),
+		Output: out,
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			defer cmd.HandleRuntimeException()
+			panic(errors.New("boom"))
+		},
+	}
+
+	// the panic is displayed once and returned instead of exiting the process
+	code, err := script.Execute(context.Background())
+
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.EqualError(t, err, "boom")
+
+	code, err = script.Execute(context.Background())
+
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.EqualError(t, err, "boom")
+}
+
+func TestExecuteNestedHandleRuntimeException(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	script := &go_console.Script{
+		Name:   "app",
+		Input:  input.NewArgvInput([]string{"app", "import"}),
+		Output: out,
+		Scripts: []*go_console.Script{
+			{
+				Name: "import",
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					defer cmd.HandleRuntimeException()
+					panic(errors.New("boom"))
+				},
+			},
+		},
+	}
+
+	// same exit code as a flat script
+	code, err := script.Execute(context.Background())
+
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.EqualError(t, err, "boom")
+}
@@ -0,0 +1,105 @@
+package script
+
+import (
+	"context"
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/output"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newNestedScript(argv []string, out output.OutputInterface) *go_console.Script {
+	return &go_console.Script{
+		Name:   "app",
+		Input:  input.NewArgvInput(argv),
+		Output: out,
+		Options: []go_console.Option{
+			{Name: "dsn", Value: option.Required, DefaultValue: "sqlite://"},
+		},
+		Scripts: []*go_console.Script{
+			{
+				Name: "db",
+				Scripts: []*go_console.Script{
+					{
+						Name: "migrate",
+						Runner: func(cmd *go_console.Script) go_console.ExitCode {
+							cmd.PrintText("migrate on " + cmd.Input.Option("dsn"))
+							return go_console.ExitSuccess
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestScriptDispatchNestedScripts(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	code, err := newNestedScript([]string{"app", "--dsn", "pgsql://", "db", "migrate"}, out).
+		Execute(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, go_console.ExitSuccess, code)
+	assert.Contains(t, out.Fetch(), "migrate on pgsql://")
+}
+
+func TestScriptDispatchGroupOnly(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	code, err := newNestedScript([]string{"app", "db"}, out).Execute(context.Background())
+
+	assert.EqualError(t, err, "command 'app db' requires a sub-command")
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.Contains(t, out.Fetch(), "migrate")
+}
+
+func TestScriptUnexpectedArgument(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	script := &go_console.Script{
+		Name:   "app",
+		Input:  input.NewArgvInput([]string{"app", "extra"}),
+		Output: out,
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			return go_console.ExitSuccess
+		},
+	}
+
+	code, err := script.Execute(context.Background())
+
+	assert.EqualError(t, err, "no arguments expected, got 'extra'")
+	assert.Equal(t, go_console.ExitInvalid, code)
+}
+
+func TestScriptInheritedShortcutClash(t *testing.T) {
+	out := output.NewBufferedOutput(false, nil)
+
+	script := &go_console.Script{
+		Name:   "app",
+		Input:  input.NewArgvInput([]string{"app", "migrate"}),
+		Output: out,
+		Options: []go_console.Option{
+			{Name: "dsn", Shortcut: "d", Value: option.Required, DefaultValue: "sqlite://"},
+		},
+		Scripts: []*go_console.Script{
+			{
+				Name: "migrate",
+				Options: []go_console.Option{
+					{Name: "dry-run", Shortcut: "d", Value: option.None},
+				},
+				Runner: func(cmd *go_console.Script) go_console.ExitCode {
+					return go_console.ExitSuccess
+				},
+			},
+		},
+	}
+
+	code, err := script.Execute(context.Background())
+
+	assert.NotNil(t, err)
+	assert.Equal(t, go_console.ExitInvalid, code)
+	assert.Contains(t, out.Fetch(), err.Error())
+}
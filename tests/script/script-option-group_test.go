@@ -0,0 +1,49 @@
+package script
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/tester"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newGroupScript() *go_console.Script {
+	return &go_console.Script{
+		Name: "export",
+		Options: []go_console.Option{
+			{Name: "json", Value: option.None},
+			{Name: "table", Value: option.None},
+			{Name: "user", Value: option.Optional},
+			{Name: "password", Value: option.Optional},
+		},
+		OptionGroups: []*definition.OptionGroup{
+			definition.Exclusive("json", "table"),
+			definition.Requires("user", "password"),
+		},
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			return go_console.ExitSuccess
+		},
+	}
+}
+
+func TestOptionGroupsValidation(t *testing.T) {
+	result := tester.NewScriptTester(newGroupScript()).Execute([]string{"--json", "--user=bob", "--password=secret"})
+	assert.Nil(t, result.Error)
+
+	result = tester.NewScriptTester(newGroupScript()).Execute([]string{"--json", "--table"})
+	assert.Equal(t, go_console.ExitInvalid, result.ExitCode)
+	assert.EqualError(t, result.Error, "the options '--json' and '--table' cannot be used together")
+
+	result = tester.NewScriptTester(newGroupScript()).Execute([]string{"--user=bob"})
+	assert.EqualError(t, result.Error, "the '--user' option requires '--password'")
+}
+
+func TestOptionGroupsHelp(t *testing.T) {
+	result := tester.NewScriptTester(newGroupScript()).Execute([]string{"--help"})
+
+	assert.Contains(t, result.Display(), "Option groups:")
+	assert.Contains(t, result.Display(), "--json, --table: mutually exclusive")
+	assert.Contains(t, result.Display(), "--user requires --password")
+}
@@ -0,0 +1,36 @@
+package script
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input/definition"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/tester"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestScriptSyntax(t *testing.T) {
+	gnu := definition.GnuSyntax()
+
+	newScript := func() *go_console.Script {
+		return &go_console.Script{
+			Name:   "build",
+			Syntax: &gnu,
+			Options: []go_console.Option{
+				{Name: "target", Value: option.Optional | option.List},
+			},
+			Runner: func(cmd *go_console.Script) go_console.ExitCode {
+				cmd.PrintText(cmd.Input.OptionList("target")[1])
+				return go_console.ExitSuccess
+			},
+		}
+	}
+
+	result := tester.NewScriptTester(newScript()).Execute([]string{"--tar=linux,darwin"})
+
+	assert.Nil(t, result.Error)
+	assert.Contains(t, result.Display(), "darwin")
+
+	result = tester.NewScriptTester(newScript()).Execute([]string{"--ver"})
+	assert.EqualError(t, result.Error, "the '--ver' option is ambiguous, it could be '--version', '--verbose'")
+}
@@ -0,0 +1,30 @@
+package script
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/tester"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTypedAccessorError(t *testing.T) {
+	script := &go_console.Script{
+		Name: "retry",
+		Options: []go_console.Option{
+			{Name: "retries", Value: option.Required, DefaultValue: "3"},
+		},
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			cmd.PrintText(cmd.Input.Option("retries"))
+			cmd.Input.OptionInt("retries")
+			return go_console.ExitSuccess
+		},
+	}
+
+	result := tester.NewScriptTester(script).Execute([]string{"--retries=many"})
+
+	assert.Equal(t, go_console.ExitInvalid, result.ExitCode)
+	assert.EqualError(t, result.Error, "the '--retries' option expects an integer, 'many' given")
+	assert.Contains(t, result.Display(), "[ERROR] the '--retries' option expects an integer, 'many' given")
+	assert.Contains(t, result.Display(), "Usage:")
+}
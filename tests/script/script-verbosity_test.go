@@ -0,0 +1,60 @@
+package script
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/tester"
+	"github.com/DrSmithFr/go-console/verbosity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newVerbosityScript(level *verbosity.Level) *go_console.Script {
+	return &go_console.Script{
+		Name: "verbosity",
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			*level = cmd.Verbosity()
+			return go_console.ExitSuccess
+		},
+	}
+}
+
+func TestScriptVerbosity(t *testing.T) {
+	expectations := map[string]verbosity.Level{
+		"":          verbosity.Normal,
+		"-q":        verbosity.Quiet,
+		"-v":        verbosity.Verbose,
+		"-vv":       verbosity.VeryVerbose,
+		"-vvv":      verbosity.Debug,
+		"--verbose": verbosity.Verbose,
+	}
+
+	for flag, expected := range expectations {
+		var level verbosity.Level
+		args := []string{}
+
+		if flag != "" {
+			args = append(args, flag)
+		}
+
+		tester.NewScriptTester(newVerbosityScript(&level)).Execute(args)
+		assert.Equal(t, expected, level, flag)
+	}
+}
+
+func TestCommandVerbosity(t *testing.T) {
+	var level verbosity.Level
+
+	cmd := &go_console.Command{
+		Scripts: []*go_console.Script{newVerbosityScript(&level)},
+	}
+
+	tester.NewCommandTester(cmd).Execute([]string{"-vv", "verbosity"})
+	assert.Equal(t, verbosity.VeryVerbose, level)
+
+	cmd = &go_console.Command{
+		Scripts: []*go_console.Script{newVerbosityScript(&level)},
+	}
+
+	tester.NewCommandTester(cmd).Execute([]string{"verbosity", "-v", "-v", "-v"})
+	assert.Equal(t, verbosity.Debug, level)
+}
@@ -0,0 +1,245 @@
+package tester
+
+import (
+	"github.com/DrSmithFr/go-console"
+	"github.com/DrSmithFr/go-console/input/argument"
+	"github.com/DrSmithFr/go-console/input/option"
+	"github.com/DrSmithFr/go-console/question"
+	"github.com/DrSmithFr/go-console/tester"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func greetRunner(cmd *go_console.Script) go_console.ExitCode {
+	name := cmd.Input.Argument("name")
+
+	if name == "" {
+		name = cmd.QuestionHelper().Ask(question.NewQuestion("What is your name?"))
+	}
+
+	cmd.PrintText("Hello " + name)
+
+	if option.Defined == cmd.Input.Option("yell") {
+		cmd.PrintText("HELLO")
+	}
+
+	return go_console.ExitSuccess
+}
+
+func newGreetScript() *go_console.Script {
+	return &go_console.Script{
+		Name: "greet",
+		Arguments: []go_console.Argument{
+			{Name: "name", Value: argument.Optional},
+		},
+		Options: []go_console.Option{
+			{Name: "yell", Shortcut: "y", Value: option.None},
+		},
+		Runner: greetRunner,
+	}
+}
+
+func TestScriptTester(t *testing.T) {
+	result := tester.
+		NewScriptTester(newGreetScript()).
+		Execute([]string{"John", "-y"})
+
+	assert.Nil(t, result.Error)
+	assert.Equal(t, go_console.ExitSuccess, result.ExitCode)
+	assert.Equal(t, "John", result.Arguments()["name"])
+	assert.Equal(t, option.Defined, result.Options()["yell"])
+	assert.Contains(t, result.Display(), "Hello John")
+	assert.Contains(t, result.Display(), "HELLO")
+}
+
+func TestScriptTesterInputs(t *testing.T) {
+	result := tester.
+		NewScriptTester(newGreetScript()).
+		SetInputs([]string{"Jane"}).
+		Execute([]string{})
+
+	assert.Equal(t, go_console.ExitSuccess, result.ExitCode)
+	assert.Contains(t, result.Display(), "What is your name?")
+	assert.Contains(t, result.Display(), "Hello Jane")
+}
+
+func TestScriptTesterParsingError(t *testing.T) {
+	result := tester.
+		NewScriptTester(newGreetScript()).
+		Execute([]string{"--unknown"})
+
+	assert.NotNil(t, result.Error)
+	assert.Equal(t, go_console.ExitInvalid, result.ExitCode)
+	assert.Contains(t, result.Display(), "the '--unknown' option does not exist")
+}
+
+func TestScriptTesterRuntimeError(t *testing.T) {
+	script := &go_console.Script{
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			panic("runtime error")
+		},
+	}
+
+	result := tester.NewScriptTester(script).Execute([]string{})
+
+	assert.EqualError(t, result.Error, "runtime error")
+	assert.Equal(t, go_console.ExitError, result.ExitCode)
+}
+
+func TestScriptTesterDecorated(t *testing.T) {
+	result := tester.
+		NewScriptTester(newGreetScript()).
+		SetDecorated(true).
+		Execute([]string{"--unknown"})
+
+	assert.Contains(t, result.Output(), "\033[")
+	assert.NotContains(t, result.Display(), "\033[")
+}
+
+func TestCommandTester(t *testing.T) {
+	cmd := &go_console.Command{
+		UseNamespace: true,
+		Scripts: []*go_console.Script{
+			{
+				Name:      "app:greet",
+				Arguments: []go_console.Argument{{Name: "name", Value: argument.Optional}},
+				Options:   []go_console.Option{{Name: "yell", Value: option.None}},
+				Runner:    greetRunner,
+			},
+		},
+	}
+
+	result := tester.
+		NewCommandTester(cmd).
+		SetInputs([]string{"Jane"}).
+		Execute([]string{"a:g"})
+
+	assert.Nil(t, result.Error)
+	assert.Equal(t, go_console.ExitSuccess, result.ExitCode)
+	assert.Equal(t, "", result.Arguments()["name"])
+	assert.Contains(t, result.Display(), "Hello Jane")
+}
+
+func TestCommandTesterUnknownScript(t *testing.T) {
+	cmd := &go_console.Command{
+		Scripts: []*go_console.Script{newGreetScript()},
+	}
+
+	result := tester.NewCommandTester(cmd).Execute([]string{"foo"})
+
+	assert.NotNil(t, result.Error)
+	assert.Equal(t, go_console.ExitInvalid, result.ExitCode)
+	assert.Contains(t, result.Display(), "Command 'foo' is not defined.")
+}
+
+func TestHelpShowsEnv(t *testing.T) {
+	script := &go_console.Script{
+		Name: "migrate",
+		Options: []go_console.Option{
+			{Name: "dsn", Value: option.Required, DefaultValue: "sqlite", Env: "APP_DB_DSN"},
+		},
+		Runner: greetRunner,
+	}
+
+	result := tester.NewScriptTester(script).Execute([]string{"--help"})
+
+	assert.Contains(t, result.Display(), `[default: "sqlite"] [env: APP_DB_DSN]`)
+}
+
+func TestTesterReuse(t *testing.T) {
+	scriptTester := tester.NewScriptTester(newGreetScript())
+
+	assert.Contains(t, scriptTester.Execute([]string{"John"}).Display(), "Hello John")
+	assert.Contains(t, scriptTester.Execute([]string{"Jane"}).Display(), "Hello Jane")
+
+	commandTester := tester.NewCommandTester(&go_console.Command{
+		Scripts: []*go_console.Script{newGreetScript()},
+	})
+
+	assert.Contains(t, commandTester.Execute([]string{"greet", "John"}).Display(), "Hello John")
+	assert.Contains(t, commandTester.Execute([]string{"greet", "Jane"}).Display(), "Hello Jane")
+
+	result := commandTester.Execute([]string{"foo"})
+
+	assert.Equal(t, go_console.ExitInvalid, result.ExitCode)
+	assert.Empty(t, result.Arguments())
+}
+
+func TestResultResolvedValues(t *testing.T) {
+	t.Setenv("APP_DSN", "pgsql")
+
+	script := &go_console.Script{
+		Name: "migrate",
+		Arguments: []go_console.Argument{
+			{Name: "version", Value: argument.Optional, DefaultValue: "latest"},
+			{Name: "steps", Value: argument.Optional | argument.List},
+		},
+		Options: []go_console.Option{
+			{Name: "dsn", Value: option.Required, DefaultValue: "sqlite", Env: "APP_DSN"},
+			{Name: "tag", Value: option.Required | option.List, DefaultValues: []string{"a"}},
+		},
+		Runner: greetRunner,
+	}
+
+	result := tester.NewScriptTester(script).Execute([]string{})
+
+	assert.Equal(t, "latest", result.Arguments()["version"])
+	assert.Equal(t, "pgsql", result.Options()["dsn"])
+	assert.Equal(t, []string{"a"}, result.OptionLists()["tag"])
+	assert.Equal(t, []string{}, result.ArgumentLists()["steps"])
+	assert.NotContains(t, result.Arguments(), "steps")
+}
+
+func TestTesterSuccessiveQuestions(t *testing.T) {
+	script := &go_console.Script{
+		Name: "ask",
+		Runner: func(cmd *go_console.Script) go_console.ExitCode {
+			first := cmd.QuestionHelper().Ask(question.NewQuestion("First?"))
+			second := cmd.QuestionHelper().Ask(question.NewQuestion("Second?"))
+
+			cmd.PrintText(first + "," + second)
+
+			return go_console.ExitSuccess
+		},
+	}
+
+	result := tester.
+		NewScriptTester(script).
+		SetInputs([]string{"one", "two"}).
+		Execute([]string{})
+
+	assert.Nil(t, result.Error)
+	assert.Contains(t, result.Display(), "one,two")
+}
+
+func TestTesterQuestionsAcrossCall(t *testing.T) {
+	cmd := &go_console.Command{
+		Scripts: []*go_console.Script{
+			{
+				Name: "first",
+				Runner: func(script *go_console.Script) go_console.ExitCode {
+					script.PrintText("first:" + script.QuestionHelper().Ask(question.NewQuestion("First?")))
+
+					code, _ := script.Call("second", []string{})
+					return code
+				},
+			},
+			{
+				Name: "second",
+				Runner: func(script *go_console.Script) go_console.ExitCode {
+					script.PrintText("second:" + script.QuestionHelper().Ask(question.NewQuestion("Second?")))
+					return go_console.ExitSuccess
+				},
+			},
+		},
+	}
+
+	result := tester.
+		NewCommandTester(cmd).
+		SetInputs([]string{"one", "two"}).
+		Execute([]string{"first"})
+
+	assert.Nil(t, result.Error)
+	assert.Contains(t, result.Display(), "first:one")
+	assert.Contains(t, result.Display(), "second:two")
+}